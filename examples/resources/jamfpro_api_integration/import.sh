@@ -0,0 +1,5 @@
+# Import by ID
+terraform import jamfpro_api_integration.example 42
+
+# Import by name
+terraform import jamfpro_api_integration.example "name:Inventory Sync"
@@ -0,0 +1,10 @@
+resource "jamfpro_api_role" "inventory" {
+  name       = "Inventory Reader"
+  privileges = ["Read Computers", "Read Smart Computer Groups", "Read Static Computer Groups"]
+}
+
+resource "jamfpro_api_integration" "inventory" {
+  display_name                  = "Inventory Sync"
+  authorization_scopes          = [jamfpro_api_role.inventory.name]
+  access_token_lifetime_seconds = 600
+}
@@ -0,0 +1,12 @@
+resource "jamfpro_api_integration_client_credentials" "inventory" {
+  api_integration_id = jamfpro_api_integration.inventory.id
+
+  rotation_triggers = {
+    rotated_on = "2026-10-01"
+  }
+}
+
+output "inventory_client_secret" {
+  value     = jamfpro_api_integration_client_credentials.inventory.client_secret
+  sensitive = true
+}
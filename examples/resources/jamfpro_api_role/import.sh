@@ -0,0 +1,5 @@
+# Import by ID
+terraform import jamfpro_api_role.example 42
+
+# Import by name
+terraform import jamfpro_api_role.example "name:Terraform"
@@ -0,0 +1,4 @@
+resource "jamfpro_api_role" "inventory" {
+  name       = "Inventory Reader"
+  privileges = ["Read Computers", "Read Smart Computer Groups", "Read Static Computer Groups"]
+}
@@ -0,0 +1,8 @@
+# Import by ID
+terraform import jamfpro_computer.example 42
+
+# Import by name
+terraform import jamfpro_computer.example "name:LAB-042"
+
+# Import by serial number
+terraform import jamfpro_computer.example "serial:C02XK1ABJG5H"
@@ -0,0 +1,19 @@
+# Import by ID
+terraform import jamfpro_computergroup.example 42
+
+# Import by name
+terraform import jamfpro_computergroup.example "name:Lab Macs"
+
+# A group of the other type is converted to a static group in place on the next
+# apply, keeping its ID. With Terraform 1.8 or later, change its resource type
+# with a moved block:
+#
+#   moved {
+#     from = jamfpro_smartcomputergroup.example
+#     to   = jamfpro_computergroup.example
+#   }
+#
+# With older versions, remove it from the state of the other resource without
+# destroying it, and import it by ID:
+terraform state rm jamfpro_smartcomputergroup.example
+terraform import jamfpro_computergroup.example 42
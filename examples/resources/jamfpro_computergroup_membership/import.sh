@@ -0,0 +1,2 @@
+# Import the membership of computers 101 and 102 in computer group 12
+terraform import jamfpro_computergroup_membership.example 12:101,102
@@ -0,0 +1,4 @@
+resource "jamfpro_computergroup_membership" "lab_macs" {
+    computer_group_id = 12
+    computer_ids      = [101, 102]
+}
@@ -0,0 +1,19 @@
+# Import by ID
+terraform import jamfpro_smartcomputergroup.example 42
+
+# Import by name
+terraform import jamfpro_smartcomputergroup.example "name:Safari Users"
+
+# A group of the other type is converted to a smart group in place on the next
+# apply, keeping its ID. With Terraform 1.8 or later, change its resource type
+# with a moved block:
+#
+#   moved {
+#     from = jamfpro_computergroup.example
+#     to   = jamfpro_smartcomputergroup.example
+#   }
+#
+# With older versions, remove it from the state of the other resource without
+# destroying it, and import it by ID:
+terraform state rm jamfpro_computergroup.example
+terraform import jamfpro_smartcomputergroup.example 42
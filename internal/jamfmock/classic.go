@@ -0,0 +1,522 @@
+package jamfmock
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type classicComputer struct {
+	XMLName xml.Name               `xml:"computer" json:"-"`
+	General classicComputerGeneral `xml:"general" json:"general"`
+}
+
+type classicComputerGeneral struct {
+	ID           int    `xml:"id" json:"id"`
+	Name         string `xml:"name" json:"name"`
+	SerialNumber string `xml:"serial_number" json:"serial_number"`
+	Udid         string `xml:"udid" json:"udid"`
+}
+
+type classicGroup struct {
+	XMLName   xml.Name             `xml:"computer_group" json:"-"`
+	ID        int                  `xml:"id" json:"id"`
+	Name      string               `xml:"name" json:"name"`
+	IsSmart   bool                 `xml:"is_smart" json:"is_smart"`
+	Criteria  []classicCriterion   `xml:"criteria>criterion" json:"criteria"`
+	Computers []classicGroupMember `xml:"computers>computer" json:"computers"`
+	// Additions and Deletions change the members of an existing static group
+	// instead of replacing them. They are never returned.
+	Additions []classicGroupMember `xml:"computer_additions>computer,omitempty" json:"computer_additions,omitempty"`
+	Deletions []classicGroupMember `xml:"computer_deletions>computer,omitempty" json:"computer_deletions,omitempty"`
+}
+
+// classicGroupFields records which fields of a computer group a request sets.
+// Like Jamf Pro, updates keep the fields a request leaves out.
+type classicGroupFields struct {
+	Name      present `xml:"name" json:"name"`
+	IsSmart   present `xml:"is_smart" json:"is_smart"`
+	Criteria  present `xml:"criteria" json:"criteria"`
+	Computers present `xml:"computers" json:"computers"`
+}
+
+// present is true when an element or field is present, even if empty.
+type present bool
+
+func (p *present) UnmarshalXML(d *xml.Decoder, _ xml.StartElement) error {
+	*p = true
+	return d.Skip()
+}
+
+func (p *present) UnmarshalJSON([]byte) error {
+	*p = true
+	return nil
+}
+
+type classicCriterion struct {
+	Name         string `xml:"name" json:"name"`
+	Priority     int    `xml:"priority" json:"priority"`
+	AndOr        string `xml:"and_or" json:"and_or"`
+	SearchType   string `xml:"search_type" json:"search_type"`
+	Value        string `xml:"value" json:"value"`
+	OpeningParen bool   `xml:"opening_paren" json:"opening_paren"`
+	ClosingParen bool   `xml:"closing_paren" json:"closing_paren"`
+}
+
+type classicGroupMember struct {
+	ID           int    `xml:"id" json:"id"`
+	Name         string `xml:"name" json:"name"`
+	SerialNumber string `xml:"serial_number" json:"serial_number"`
+	Udid         string `xml:"udid" json:"udid"`
+}
+
+// classicIDResponse is the body returned by the Classic API for writes.
+type classicIDResponse struct {
+	XMLName xml.Name
+	ID      int `xml:"id"`
+}
+
+// classicLookup splits a Classic API path such as /JSSResource/computers/serialnumber/ABC
+// into its lookup key and value.
+func classicLookup(r *http.Request, collection string) (key string, value string) {
+	rest := strings.Trim(strings.TrimPrefix(r.URL.Path, "/JSSResource/"+collection), "/")
+	key, value, _ = strings.Cut(rest, "/")
+	return key, value
+}
+
+// decodeClassic decodes a Classic API request body, which is XML, or JSON
+// optionally wrapped in an object keyed by the root element name.
+func decodeClassic(r *http.Request, root string, v any) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(r.Header.Get("Content-Type"), "json") {
+		return xml.Unmarshal(body, v)
+	}
+	var wrapper map[string]json.RawMessage
+	if err := json.Unmarshal(body, &wrapper); err == nil {
+		if inner, ok := wrapper[root]; ok {
+			body = inner
+		}
+	}
+	return json.Unmarshal(body, v)
+}
+
+// writeClassic writes v as JSON wrapped in an object keyed by root when the
+// client accepts JSON, and as XML otherwise.
+func writeClassic(w http.ResponseWriter, r *http.Request, status int, root string, v any) {
+	if strings.Contains(r.Header.Get("Accept"), "json") {
+		writeJSON(w, status, map[string]any{root: v})
+		return
+	}
+	writeXML(w, status, v)
+}
+
+func (s *Server) handleComputers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, value := classicLookup(r, "computers")
+	if key == "" {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		computers := make([]map[string]any, 0)
+		for _, c := range s.computers.list() {
+			computers = append(computers, map[string]any{"id": c.General.ID, "name": c.General.Name})
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"computers": computers})
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		if key != "id" || value != "0" {
+			writeError(w, http.StatusConflict, "new computers must be created with id 0")
+			return
+		}
+		var c classicComputer
+		if err := decodeClassic(r, "computer", &c); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if !s.validateComputer(w, c, 0) {
+			return
+		}
+		if c.General.Udid == "" {
+			c.General.Udid = newUdid()
+		}
+		created := s.computers.create(s.propagationDelay, func(id int) classicComputer {
+			c.General.ID = id
+			return c
+		})
+		writeXML(w, http.StatusCreated, classicIDResponse{XMLName: xml.Name{Local: "computer"}, ID: created.General.ID})
+		return
+	}
+
+	id, found := s.findComputer(key, value)
+	if !found {
+		writeError(w, http.StatusNotFound, "The server has not found anything matching the request URI")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		c, ok := s.computers.get(id)
+		if !ok {
+			writeError(w, http.StatusNotFound, "The server has not found anything matching the request URI")
+			return
+		}
+		writeClassic(w, r, http.StatusOK, "computer", c)
+	case http.MethodPut:
+		current, _ := s.computers.latest(id)
+		var c classicComputer
+		if err := decodeClassic(r, "computer", &c); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		c.General.ID = id
+		if c.General.Udid == "" {
+			c.General.Udid = current.General.Udid
+		}
+		if !s.validateComputer(w, c, id) {
+			return
+		}
+		s.computers.update(id, s.propagationDelay, c)
+		writeXML(w, http.StatusCreated, classicIDResponse{XMLName: xml.Name{Local: "computer"}, ID: id})
+	case http.MethodDelete:
+		s.computers.delete(id)
+		writeXML(w, http.StatusOK, classicIDResponse{XMLName: xml.Name{Local: "computer"}, ID: id})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) validateComputer(w http.ResponseWriter, c classicComputer, id int) bool {
+	if c.General.Name == "" {
+		writeError(w, http.StatusConflict, "Problem with computer name")
+		return false
+	}
+	if c.General.SerialNumber == "" {
+		return true
+	}
+	other, found := s.computers.find(func(o classicComputer) bool { return o.General.SerialNumber == c.General.SerialNumber })
+	if found && other != id {
+		writeError(w, http.StatusConflict, "Duplicate serial number")
+		return false
+	}
+	return true
+}
+
+// findComputer returns the ID of the computer matching a Classic API lookup.
+func (s *Server) findComputer(key, value string) (int, bool) {
+	var match func(classicComputer) bool
+	switch key {
+	case "id":
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, false
+		}
+		_, ok := s.computers.latest(id)
+		return id, ok
+	case "name":
+		match = func(c classicComputer) bool { return c.General.Name == value }
+	case "serialnumber":
+		match = func(c classicComputer) bool { return c.General.SerialNumber == value }
+	case "udid":
+		match = func(c classicComputer) bool { return c.General.Udid == value }
+	default:
+		return 0, false
+	}
+	return s.computers.find(match)
+}
+
+func (s *Server) handleComputerGroups(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, value := classicLookup(r, "computergroups")
+	if key == "" {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		groups := make([]map[string]any, 0)
+		for _, g := range s.groups.list() {
+			groups = append(groups, map[string]any{"id": g.ID, "name": g.Name, "is_smart": g.IsSmart})
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"computer_groups": groups})
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		if key != "id" || value != "0" {
+			writeError(w, http.StatusConflict, "new computer groups must be created with id 0")
+			return
+		}
+		g, ok := s.decodeGroup(w, r, 0, classicGroup{})
+		if !ok {
+			return
+		}
+		created := s.groups.create(s.propagationDelay, func(id int) classicGroup {
+			g.ID = id
+			return g
+		})
+		writeXML(w, http.StatusCreated, classicIDResponse{XMLName: xml.Name{Local: "computer_group"}, ID: created.ID})
+		return
+	}
+
+	var id int
+	var found bool
+	switch key {
+	case "id":
+		var err error
+		id, err = strconv.Atoi(value)
+		if err == nil {
+			_, found = s.groups.latest(id)
+		}
+	case "name":
+		id, found = s.groups.find(func(g classicGroup) bool { return g.Name == value })
+	}
+	if !found {
+		writeError(w, http.StatusNotFound, "The server has not found anything matching the request URI")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		g, ok := s.groups.get(id)
+		if !ok {
+			writeError(w, http.StatusNotFound, "The server has not found anything matching the request URI")
+			return
+		}
+		writeClassic(w, r, http.StatusOK, "computer_group", s.renderGroup(g))
+	case http.MethodPut:
+		existing, _ := s.groups.latest(id)
+		g, ok := s.decodeGroup(w, r, id, existing)
+		if !ok {
+			return
+		}
+		g.ID = id
+		s.groups.update(id, s.propagationDelay, g)
+		writeXML(w, http.StatusCreated, classicIDResponse{XMLName: xml.Name{Local: "computer_group"}, ID: id})
+	case http.MethodDelete:
+		s.groups.delete(id)
+		writeXML(w, http.StatusOK, classicIDResponse{XMLName: xml.Name{Local: "computer_group"}, ID: id})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// decodeGroup decodes and validates a computer group, resolving its members to
+// computer IDs. Fields an update leaves out keep their values in existing, and
+// members added or deleted with computer_additions and computer_deletions are
+// applied to those of existing.
+func (s *Server) decodeGroup(w http.ResponseWriter, r *http.Request, id int, existing classicGroup) (classicGroup, bool) {
+	var g classicGroup
+	var fields classicGroupFields
+	body, err := io.ReadAll(r.Body)
+	if err == nil {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		err = decodeClassic(r, "computer_group", &g)
+	}
+	if err == nil {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		err = decodeClassic(r, "computer_group", &fields)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return g, false
+	}
+	changesMembers := len(g.Additions) > 0 || len(g.Deletions) > 0
+	if changesMembers && existing.IsSmart {
+		writeError(w, http.StatusConflict, "Unable to add or delete computers of a smart group")
+		return g, false
+	}
+	if id != 0 {
+		if !fields.Name {
+			g.Name = existing.Name
+		}
+		if !fields.IsSmart {
+			g.IsSmart = existing.IsSmart
+		}
+		if !fields.Criteria {
+			g.Criteria = existing.Criteria
+		}
+		if !bool(fields.Computers) && !changesMembers {
+			g.Computers = existing.Computers
+		}
+	}
+	if g.Name == "" {
+		writeError(w, http.StatusConflict, "Problem with computer group name")
+		return g, false
+	}
+	other, found := s.groups.find(func(o classicGroup) bool { return o.Name == g.Name })
+	if found && other != id {
+		writeError(w, http.StatusConflict, "Duplicate name")
+		return g, false
+	}
+	g.IsSmart = g.IsSmart || len(g.Criteria) > 0
+
+	members, ok := s.resolveMembers(w, g.Computers)
+	if !ok {
+		return g, false
+	}
+	if changesMembers {
+		additions, ok := s.resolveMembers(w, g.Additions)
+		if !ok {
+			return g, false
+		}
+		deletions, ok := s.resolveMembers(w, g.Deletions)
+		if !ok {
+			return g, false
+		}
+		members = append([]classicGroupMember{}, existing.Computers...)
+		for _, a := range additions {
+			if !containsMember(members, a.ID) {
+				members = append(members, a)
+			}
+		}
+		kept := make([]classicGroupMember, 0, len(members))
+		for _, m := range members {
+			if !containsMember(deletions, m.ID) {
+				kept = append(kept, m)
+			}
+		}
+		members = kept
+	}
+	g.Computers = members
+	g.Additions, g.Deletions = nil, nil
+	return g, true
+}
+
+// resolveMembers resolves members of a group, given by ID, serial number or name, to computer IDs.
+func (s *Server) resolveMembers(w http.ResponseWriter, given []classicGroupMember) ([]classicGroupMember, bool) {
+	members := make([]classicGroupMember, 0, len(given))
+	for _, m := range given {
+		var computerID int
+		var ok bool
+		switch {
+		case m.ID != 0:
+			computerID, ok = s.findComputer("id", strconv.Itoa(m.ID))
+		case m.SerialNumber != "":
+			computerID, ok = s.findComputer("serialnumber", m.SerialNumber)
+		default:
+			computerID, ok = s.findComputer("name", m.Name)
+		}
+		if !ok {
+			writeError(w, http.StatusConflict, fmt.Sprintf("Unable to match computer %+v", m))
+			return nil, false
+		}
+		members = append(members, classicGroupMember{ID: computerID})
+	}
+	return members, true
+}
+
+func containsMember(members []classicGroupMember, id int) bool {
+	for _, m := range members {
+		if m.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// renderGroup fills in the details of the members of a group from the current computers.
+func (s *Server) renderGroup(g classicGroup) classicGroup {
+	members := make([]classicGroupMember, 0, len(g.Computers))
+	for _, id := range s.members(g) {
+		c, ok := s.computers.latest(id)
+		if !ok {
+			continue
+		}
+		members = append(members, classicGroupMember{
+			ID:           c.General.ID,
+			Name:         c.General.Name,
+			SerialNumber: c.General.SerialNumber,
+			Udid:         c.General.Udid,
+		})
+	}
+	g.Computers = members
+	// Jamf Pro returns criteria in the order it evaluates them.
+	criteria := append([]classicCriterion{}, g.Criteria...)
+	sort.SliceStable(criteria, func(i, j int) bool { return criteria[i].Priority < criteria[j].Priority })
+	g.Criteria = criteria
+	return g
+}
+
+// members returns the IDs of the computers in a group. Smart groups are
+// evaluated against the names and serial numbers of the computers only,
+// combining criteria from left to right without regard for parentheses.
+func (s *Server) members(g classicGroup) []int {
+	ids := make([]int, 0)
+	if !g.IsSmart {
+		for _, m := range g.Computers {
+			ids = append(ids, m.ID)
+		}
+		return ids
+	}
+
+	criteria := append([]classicCriterion{}, g.Criteria...)
+	sort.SliceStable(criteria, func(i, j int) bool { return criteria[i].Priority < criteria[j].Priority })
+	for _, c := range s.computers.list() {
+		matches := false
+		for i, criterion := range criteria {
+			m := criterion.match(c)
+			switch {
+			case i == 0:
+				matches = m
+			case criterion.AndOr == "or":
+				matches = matches || m
+			default:
+				matches = matches && m
+			}
+		}
+		if matches {
+			ids = append(ids, c.General.ID)
+		}
+	}
+	return ids
+}
+
+// match reports whether a computer matches a criterion. Only the Computer
+// Name and Serial Number criteria are supported, other criteria match nothing.
+func (criterion classicCriterion) match(c classicComputer) bool {
+	var value string
+	switch criterion.Name {
+	case "Computer Name":
+		value = c.General.Name
+	case "Serial Number":
+		value = c.General.SerialNumber
+	default:
+		return false
+	}
+	value, expected := strings.ToLower(value), strings.ToLower(criterion.Value)
+	switch criterion.SearchType {
+	case "is":
+		return value == expected
+	case "is not":
+		return value != expected
+	case "like":
+		return strings.Contains(value, expected)
+	case "not like":
+		return !strings.Contains(value, expected)
+	}
+	return false
+}
+
+func newUdid() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("jamfmock: cannot generate UDID: %s", err))
+	}
+	return strings.ToUpper(fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]))
+}
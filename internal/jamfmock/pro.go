@@ -0,0 +1,640 @@
+package jamfmock
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// proCollection serves the Pro API routes of one object type, e.g. /api/v1/categories.
+type proCollection struct {
+	name      string
+	nameField string
+	defaults  map[string]any
+	objects   *store[map[string]any]
+	// check, if set, validates the other fields of an object.
+	check func(obj map[string]any) error
+	// assign, if set, fills in the fields the server assigns to a new object.
+	assign func(obj map[string]any)
+	// actions serve the POST routes of an object, e.g. client-credentials for
+	// /api/v1/api-integrations/1/client-credentials, by name.
+	actions map[string]func(w http.ResponseWriter, obj map[string]any)
+}
+
+func newProCollection(name string, defaults map[string]any) *proCollection {
+	return &proCollection{
+		name:      name,
+		nameField: "name",
+		defaults:  defaults,
+		objects:   newStore[map[string]any](),
+	}
+}
+
+func (c *proCollection) handler(s *Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		rest := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1/"+c.name), "/")
+		if rest == "" {
+			switch r.Method {
+			case http.MethodGet:
+				c.list(w, r)
+			case http.MethodPost:
+				c.create(w, r, s.propagationDelay)
+			default:
+				writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			}
+			return
+		}
+
+		rest, action, _ := strings.Cut(rest, "/")
+		id, err := strconv.Atoi(rest)
+		if err == nil && action != "" {
+			c.serveAction(w, r, id, action)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("no route for %s", r.URL.Path))
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			obj, ok := c.objects.get(id)
+			if !ok {
+				c.notFound(w, id)
+				return
+			}
+			writeJSON(w, http.StatusOK, obj)
+		case http.MethodPut:
+			c.update(w, r, id, s.propagationDelay)
+		case http.MethodDelete:
+			if !c.objects.delete(id) {
+				c.notFound(w, id)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	})
+}
+
+// serveAction serves a POST route of the object with the given ID.
+func (c *proCollection) serveAction(w http.ResponseWriter, r *http.Request, id int, action string) {
+	serve, ok := c.actions[action]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no route for %s", r.URL.Path))
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	obj, ok := c.objects.latest(id)
+	if !ok {
+		c.notFound(w, id)
+		return
+	}
+	serve(w, obj)
+}
+
+func (c *proCollection) notFound(w http.ResponseWriter, id int) {
+	writeError(w, http.StatusNotFound, fmt.Sprintf("%s with id %d not found", c.name, id))
+}
+
+func (c *proCollection) decode(w http.ResponseWriter, r *http.Request) (map[string]any, bool) {
+	var body map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid JSON body: %s", err))
+		return nil, false
+	}
+	delete(body, "id")
+	delete(body, "href")
+	return body, true
+}
+
+// validate checks the name of obj is set and unique among the other objects,
+// and its other fields with check.
+func (c *proCollection) validate(w http.ResponseWriter, obj map[string]any, id int) bool {
+	name, _ := obj[c.nameField].(string)
+	if name == "" {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("%s is required", c.nameField))
+		return false
+	}
+	other, found := c.objects.find(func(o map[string]any) bool { return o[c.nameField] == name })
+	if found && other != id {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("DUPLICATE_FIELD %s", c.nameField))
+		return false
+	}
+	if c.check != nil {
+		if err := c.check(obj); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return false
+		}
+	}
+	return true
+}
+
+func (c *proCollection) create(w http.ResponseWriter, r *http.Request, staleReads int) {
+	body, ok := c.decode(w, r)
+	if !ok {
+		return
+	}
+	obj := make(map[string]any, len(c.defaults)+1)
+	for k, v := range c.defaults {
+		obj[k] = v
+	}
+	for k, v := range body {
+		obj[k] = v
+	}
+	if !c.validate(w, obj, 0) {
+		return
+	}
+	created := c.objects.create(staleReads, func(id int) map[string]any {
+		obj["id"] = strconv.Itoa(id)
+		if c.assign != nil {
+			c.assign(obj)
+		}
+		return obj
+	})
+
+	// The real API only returns the ID and href. The full object is returned as
+	// well so that clients decoding the response into the object still work.
+	response := map[string]any{"href": path.Join("/api/v1", c.name, created["id"].(string))}
+	for k, v := range created {
+		response[k] = v
+	}
+	writeJSON(w, http.StatusCreated, response)
+}
+
+func (c *proCollection) update(w http.ResponseWriter, r *http.Request, id int, staleReads int) {
+	current, ok := c.objects.latest(id)
+	if !ok {
+		c.notFound(w, id)
+		return
+	}
+	body, ok := c.decode(w, r)
+	if !ok {
+		return
+	}
+	obj := make(map[string]any, len(current))
+	for k, v := range current {
+		obj[k] = v
+	}
+	for k, v := range body {
+		obj[k] = v
+	}
+	if !c.validate(w, obj, id) {
+		return
+	}
+	c.objects.update(id, staleReads, obj)
+	writeJSON(w, http.StatusOK, obj)
+}
+
+func (c *proCollection) list(w http.ResponseWriter, r *http.Request) {
+	writeList(w, r, c.objects.list(), nil)
+}
+
+// writeList serves a page of objects, supporting the page, page-size, sort
+// and filter query parameters of the Pro API. The objects of the page are
+// passed through view, if set, after filtering and sorting.
+func writeList(w http.ResponseWriter, r *http.Request, objects []map[string]any, view func(map[string]any) map[string]any) {
+	query := r.URL.Query()
+
+	filter, err := parseFilter(query.Get("filter"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	results := make([]map[string]any, 0)
+	for _, obj := range objects {
+		if filter.match(obj) {
+			results = append(results, obj)
+		}
+	}
+	sortObjects(results, query["sort"])
+
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, err := strconv.Atoi(query.Get("page-size"))
+	if err != nil || pageSize <= 0 {
+		pageSize = 100
+	}
+	start := min(page*pageSize, len(results))
+	end := min(start+pageSize, len(results))
+	paged := results[start:end]
+	if view != nil {
+		for i, obj := range paged {
+			paged[i] = view(obj)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"totalCount": len(results),
+		"results":    paged,
+	})
+}
+
+// field returns the value of a field of obj, where a dotted name such as
+// general.name selects a field of a nested object.
+func field(obj map[string]any, name string) any {
+	var v any = obj
+	for _, key := range strings.Split(name, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	return v
+}
+
+// sortObjects sorts objects by the given sort expressions (e.g. "name:asc"),
+// falling back to ascending IDs.
+func sortObjects(objects []map[string]any, sorts []string) {
+	var keys []string
+	for _, s := range sorts {
+		keys = append(keys, strings.Split(s, ",")...)
+	}
+	keys = append(keys, "id:asc")
+
+	sort.SliceStable(objects, func(i, j int) bool {
+		for _, key := range keys {
+			name, direction, _ := strings.Cut(key, ":")
+			a, b := sortValue(field(objects[i], name)), sortValue(field(objects[j], name))
+			if a == b {
+				continue
+			}
+			if direction == "desc" {
+				return b < a
+			}
+			return a < b
+		}
+		return false
+	})
+}
+
+func sortValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		if n, err := strconv.Atoi(v); err == nil {
+			return fmt.Sprintf("%020d", n)
+		}
+		return strings.ToLower(v)
+	case float64:
+		return fmt.Sprintf("%020.0f", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// filter is a parsed RSQL expression, as an OR of ANDed comparisons.
+// Grouping with parentheses is not supported.
+type filter [][]comparison
+
+type comparison struct {
+	field    string
+	operator string
+	value    string
+}
+
+func parseFilter(expr string) (filter, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	var f filter
+	for _, or := range strings.Split(expr, ",") {
+		var and []comparison
+		for _, term := range strings.Split(or, ";") {
+			operator := "=="
+			field, value, ok := strings.Cut(term, "==")
+			if !ok {
+				operator = "!="
+				field, value, ok = strings.Cut(term, "!=")
+			}
+			if !ok || field == "" {
+				return nil, fmt.Errorf("unsupported filter term %q", term)
+			}
+			and = append(and, comparison{field: field, operator: operator, value: strings.Trim(value, `"'`)})
+		}
+		f = append(f, and)
+	}
+	return f, nil
+}
+
+func (f filter) match(obj map[string]any) bool {
+	if len(f) == 0 {
+		return true
+	}
+	for _, and := range f {
+		matched := true
+		for _, c := range and {
+			if c.match(obj) != (c.operator == "==") {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// match reports whether the field equals the value, where * in the value is a wildcard.
+func (c comparison) match(obj map[string]any) bool {
+	var actual string
+	switch v := field(obj, c.field).(type) {
+	case nil:
+		return false
+	case float64:
+		actual = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		actual = fmt.Sprint(v)
+	}
+	actual, pattern := strings.ToLower(actual), strings.ToLower(c.value)
+
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return actual == pattern
+	}
+	if !strings.HasPrefix(actual, parts[0]) {
+		return false
+	}
+	actual = actual[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(actual, part)
+		if i < 0 {
+			return false
+		}
+		actual = actual[i+len(part):]
+	}
+	return strings.HasSuffix(actual, parts[len(parts)-1])
+}
+
+// inventorySections maps the sections of the computers-inventory endpoint to
+// the fields of a computer holding them.
+var inventorySections = map[string]string{
+	"GENERAL":              "general",
+	"HARDWARE":             "hardware",
+	"OPERATING_SYSTEM":     "operatingSystem",
+	"USER_AND_LOCATION":    "userAndLocation",
+	"EXTENSION_ATTRIBUTES": "extensionAttributes",
+	"GROUP_MEMBERSHIPS":    "groupMemberships",
+}
+
+// handleComputersInventory serves the computers of the Pro API, from the
+// computers of the Classic API, with the sections selected by the section
+// query parameter (GENERAL by default).
+func (s *Server) handleComputersInventory(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	sections := r.URL.Query()["section"]
+	if len(sections) == 0 {
+		sections = []string{"GENERAL"}
+	}
+	for _, section := range sections {
+		if _, ok := inventorySections[section]; !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown section %s", section))
+			return
+		}
+	}
+
+	rest := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1/computers-inventory"), "/")
+	if rest == "" {
+		computers := make([]map[string]any, 0)
+		for _, c := range s.computers.list() {
+			computers = append(computers, s.inventory(c))
+		}
+		writeList(w, r, computers, func(c map[string]any) map[string]any {
+			return selectSections(c, sections)
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(rest)
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no route for %s", r.URL.Path))
+		return
+	}
+	c, ok := s.computers.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("computer with id %d not found", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, selectSections(s.inventory(c), sections))
+}
+
+// inventory returns the inventory of a computer with every section. Sections
+// the Classic API does not hold are filled in with the details of a typical Mac.
+func (s *Server) inventory(c classicComputer) map[string]any {
+	memberships := make([]map[string]any, 0)
+	for _, g := range s.groups.list() {
+		for _, id := range s.members(g) {
+			if id == c.General.ID {
+				memberships = append(memberships, map[string]any{
+					"groupId":    strconv.Itoa(g.ID),
+					"groupName":  g.Name,
+					"smartGroup": g.IsSmart,
+				})
+			}
+		}
+	}
+	return map[string]any{
+		"id":   strconv.Itoa(c.General.ID),
+		"udid": c.General.Udid,
+		"general": map[string]any{
+			"name":             c.General.Name,
+			"lastIpAddress":    "192.0.2.10",
+			"lastReportedIp":   "10.0.0.10",
+			"lastContactTime":  "2024-05-01T09:30:00Z",
+			"reportDate":       "2024-05-01T09:00:00Z",
+			"assetTag":         "",
+			"platform":         "Mac",
+			"supervised":       true,
+			"mdmCapable":       map[string]any{"capable": true},
+			"remoteManagement": map[string]any{"managed": true},
+		},
+		"hardware": map[string]any{
+			"make":                  "Apple",
+			"model":                 "MacBook Pro (14-inch, 2023)",
+			"modelIdentifier":       "Mac14,9",
+			"serialNumber":          c.General.SerialNumber,
+			"processorType":         "Apple M2 Pro",
+			"processorArchitecture": "arm64",
+			"totalRamMegabytes":     16384,
+			"macAddress":            "00:00:5E:00:53:01",
+			"appleSilicon":          true,
+		},
+		"operatingSystem": map[string]any{
+			"name":                     "macOS",
+			"version":                  "14.4.1",
+			"build":                    "23E224",
+			"supplementalBuildVersion": "",
+			"fileVault2Status":         "ALL_ENCRYPTED",
+		},
+		"userAndLocation": map[string]any{
+			"username":     "",
+			"realname":     "",
+			"email":        "",
+			"position":     "",
+			"phone":        "",
+			"departmentId": nil,
+			"buildingId":   nil,
+			"room":         "",
+		},
+		"extensionAttributes": []map[string]any{},
+		"groupMemberships":    memberships,
+	}
+}
+
+// selectSections returns a computer's inventory with only the given sections,
+// leaving the others null like the Pro API does.
+func selectSections(inventory map[string]any, sections []string) map[string]any {
+	selected := map[string]any{"id": inventory["id"], "udid": inventory["udid"]}
+	for _, key := range inventorySections {
+		selected[key] = nil
+	}
+	for _, section := range sections {
+		key := inventorySections[section]
+		selected[key] = inventory[key]
+	}
+	return selected
+}
+
+// handleGroupList serves the list of static or smart computer groups of the
+// Pro API, from the computer groups of the Classic API.
+func (s *Server) handleGroupList(smart bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		groups := make([]map[string]any, 0)
+		for _, g := range s.groups.list() {
+			if g.IsSmart != smart {
+				continue
+			}
+			groups = append(groups, map[string]any{
+				"id":          strconv.Itoa(g.ID),
+				"name":        g.Name,
+				"description": "",
+				"siteId":      "-1",
+			})
+		}
+		writeList(w, r, groups, nil)
+	})
+}
+
+// ApiRolePrivileges are the privileges API roles can grant on the mock server,
+// a subset of those of Jamf Pro.
+var ApiRolePrivileges = []string{
+	"Create API Integrations",
+	"Create API Roles",
+	"Create Buildings",
+	"Create Categories",
+	"Create Computers",
+	"Create Departments",
+	"Create Packages",
+	"Create Smart Computer Groups",
+	"Create Static Computer Groups",
+	"Delete API Integrations",
+	"Delete API Roles",
+	"Delete Buildings",
+	"Delete Categories",
+	"Delete Computers",
+	"Delete Departments",
+	"Delete Smart Computer Groups",
+	"Delete Static Computer Groups",
+	"Read API Integrations",
+	"Read API Roles",
+	"Read Buildings",
+	"Read Categories",
+	"Read Computers",
+	"Read Departments",
+	"Read PKI",
+	"Read SMTP Server",
+	"Read Smart Computer Groups",
+	"Read Static Computer Groups",
+	"Read Static Mobile Device Groups",
+	"Read Teacher App Settings",
+	"Read eBooks",
+	"Read iBeacon",
+	"Update API Integrations",
+	"Update API Roles",
+	"Update Buildings",
+	"Update Categories",
+	"Update Computers",
+	"Update Departments",
+	"Update Smart Computer Groups",
+	"Update Static Computer Groups",
+}
+
+// handleApiRolePrivileges serves the privileges API roles can grant.
+func (s *Server) handleApiRolePrivileges(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"privileges": ApiRolePrivileges})
+}
+
+// checkApiRole rejects API roles granting privileges that do not exist.
+func checkApiRole(obj map[string]any) error {
+	privileges, _ := obj["privileges"].([]any)
+	for _, p := range privileges {
+		name, _ := p.(string)
+		if !slices.Contains(ApiRolePrivileges, name) {
+			return fmt.Errorf("INVALID_FIELD privileges: %q is not a valid privilege", name)
+		}
+	}
+	return nil
+}
+
+// checkApiIntegration rejects API integrations granted API roles that do not exist.
+func (s *Server) checkApiIntegration(obj map[string]any) error {
+	scopes, _ := obj["authorizationScopes"].([]any)
+	for _, scope := range scopes {
+		name, _ := scope.(string)
+		if _, found := s.apiRoles.objects.find(func(o map[string]any) bool { return o["displayName"] == name }); !found {
+			return fmt.Errorf("INVALID_FIELD authorizationScopes: API role %q does not exist", name)
+		}
+	}
+	return nil
+}
+
+// assignClientID gives a new API integration its client ID.
+func assignClientID(obj map[string]any) {
+	obj["clientId"] = strings.ToLower(newUdid())
+}
+
+// handleClientCredentials generates a client secret for an API integration.
+func handleClientCredentials(w http.ResponseWriter, obj map[string]any) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"clientId":     obj["clientId"],
+		"clientSecret": newClientSecret(),
+	})
+}
+
+func newClientSecret() string {
+	b := make([]byte, 48)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("jamfmock: cannot generate client secret: %s", err))
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
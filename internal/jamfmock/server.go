@@ -0,0 +1,357 @@
+// Package jamfmock implements an in-process stand-in for a Jamf Pro server.
+//
+// It serves the OAuth token endpoint together with the Pro API and Classic API
+// routes used by the provider, keeps its objects in memory, and can inject
+// failures and delayed propagation so that the eventual consistency of a real
+// tenant can be reproduced in tests.
+package jamfmock
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials accepted by the token endpoints of every mock server.
+const (
+	ClientID     = "mock-client-id"
+	ClientSecret = "mock-client-secret"
+	Username     = "mock-user"
+	Password     = "mock-password"
+)
+
+// defaultTokenLifetime is the lifetime of the access tokens issued by the server.
+const defaultTokenLifetime = 20 * time.Minute
+
+// Fault describes an error response to return instead of serving a request.
+type Fault struct {
+	// Method restricts the fault to a HTTP method. Empty matches every method.
+	Method string
+	// Path is a prefix of the request paths the fault applies to.
+	Path string
+	// Status is the HTTP status code returned.
+	Status int
+	// Count is the number of matching requests that fail. Zero means one.
+	Count int
+	// RetryAfter, when set, is returned in the Retry-After header.
+	RetryAfter time.Duration
+	// Delay is waited before the response is written.
+	Delay time.Duration
+}
+
+type issuedToken struct {
+	issued      time.Time
+	expires     time.Time
+	invalidated bool
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+}
+
+// Server is a mock Jamf Pro server.
+type Server struct {
+	*httptest.Server
+
+	mu               sync.Mutex
+	mux              *http.ServeMux
+	faults           []*Fault
+	requests         []Request
+	tokens           map[string]issuedToken
+	tokenLifetime    time.Duration
+	revokedBefore    time.Time
+	propagationDelay int
+
+	categories      *proCollection
+	buildings       *proCollection
+	departments     *proCollection
+	apiRoles        *proCollection
+	apiIntegrations *proCollection
+	computers       *store[classicComputer]
+	groups          *store[classicGroup]
+}
+
+// NewServer starts a mock Jamf Pro server listening on a local port.
+// Callers should call Close when finished with it.
+func NewServer() *Server {
+	s := newServer()
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// NewTLSServer starts a mock Jamf Pro server using TLS with a self-signed certificate.
+func NewTLSServer() *Server {
+	s := newServer()
+	s.Server = httptest.NewTLSServer(s)
+	return s
+}
+
+func newServer() *Server {
+	s := &Server{
+		mux:           http.NewServeMux(),
+		tokens:        make(map[string]issuedToken),
+		tokenLifetime: defaultTokenLifetime,
+		categories: newProCollection("categories", map[string]any{
+			"name":     "",
+			"priority": float64(9),
+		}),
+		buildings: newProCollection("buildings", map[string]any{
+			"name":           "",
+			"streetAddress1": "",
+			"streetAddress2": "",
+			"city":           "",
+			"stateProvince":  "",
+			"zipPostalCode":  "",
+			"country":        "",
+		}),
+		departments: newProCollection("departments", map[string]any{
+			"name": "",
+		}),
+		apiRoles: newProCollection("api-roles", map[string]any{
+			"displayName": "",
+			"privileges":  []any{},
+		}),
+		apiIntegrations: newProCollection("api-integrations", map[string]any{
+			"displayName":                "",
+			"enabled":                    true,
+			"authorizationScopes":        []any{},
+			"accessTokenLifetimeSeconds": float64(1800),
+			"appType":                    "CLIENT_CREDENTIALS",
+		}),
+		computers: newStore[classicComputer](),
+		groups:    newStore[classicGroup](),
+	}
+	s.apiRoles.nameField = "displayName"
+	s.apiRoles.check = checkApiRole
+	s.apiIntegrations.nameField = "displayName"
+	s.apiIntegrations.check = s.checkApiIntegration
+	s.apiIntegrations.assign = assignClientID
+	s.apiIntegrations.actions = map[string]func(http.ResponseWriter, map[string]any){
+		"client-credentials": handleClientCredentials,
+	}
+
+	s.mux.HandleFunc("/api/oauth/token", s.handleOAuthToken)
+	s.mux.HandleFunc("/api/v1/auth/token", s.handleAuthToken)
+	s.mux.Handle("/api/v1/auth/keep-alive", s.authenticated(http.HandlerFunc(s.handleKeepAlive)))
+	s.mux.Handle("/api/v1/auth/invalidate-token", s.authenticated(http.HandlerFunc(s.handleInvalidateToken)))
+	for _, c := range []*proCollection{s.categories, s.buildings, s.departments, s.apiRoles, s.apiIntegrations} {
+		s.mux.Handle("/api/v1/"+c.name, s.authenticated(c.handler(s)))
+		s.mux.Handle("/api/v1/"+c.name+"/", s.authenticated(c.handler(s)))
+	}
+	s.mux.Handle("/api/v1/api-role-privileges", s.authenticated(http.HandlerFunc(s.handleApiRolePrivileges)))
+	s.mux.Handle("/api/v1/computers-inventory", s.authenticated(http.HandlerFunc(s.handleComputersInventory)))
+	s.mux.Handle("/api/v1/computers-inventory/", s.authenticated(http.HandlerFunc(s.handleComputersInventory)))
+	s.mux.Handle("/api/v2/computer-groups/static-groups", s.authenticated(s.handleGroupList(false)))
+	s.mux.Handle("/api/v2/computer-groups/smart-groups", s.authenticated(s.handleGroupList(true)))
+	s.mux.Handle("/JSSResource/computers", s.authenticated(http.HandlerFunc(s.handleComputers)))
+	s.mux.Handle("/JSSResource/computers/", s.authenticated(http.HandlerFunc(s.handleComputers)))
+	s.mux.Handle("/JSSResource/computergroups", s.authenticated(http.HandlerFunc(s.handleComputerGroups)))
+	s.mux.Handle("/JSSResource/computergroups/", s.authenticated(http.HandlerFunc(s.handleComputerGroups)))
+	return s
+}
+
+// InjectFault registers a fault. Faults are matched in the order they were injected.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f.Count == 0 {
+		f.Count = 1
+	}
+	s.faults = append(s.faults, &f)
+}
+
+// SetPropagationDelay sets the number of reads for which a created or updated
+// object keeps being served in its previous state (or as not found when it was
+// just created). Zero disables the delay.
+func (s *Server) SetPropagationDelay(reads int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.propagationDelay = reads
+}
+
+// SetTokenLifetime sets the lifetime of the access tokens issued from now on.
+func (s *Server) SetTokenLifetime(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokenLifetime = d
+}
+
+// RevokeTokens invalidates every access token issued so far.
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revokedBefore = time.Now()
+}
+
+// Requests returns the requests served so far, token requests included.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path})
+	fault := s.matchFault(r)
+	s.mu.Unlock()
+
+	if fault != nil {
+		if fault.Delay > 0 {
+			time.Sleep(fault.Delay)
+		}
+		if fault.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(fault.RetryAfter.Seconds())))
+		}
+		writeError(w, fault.Status, "injected fault")
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// matchFault returns the first fault matching r and consumes one of its occurrences.
+// Must be called with s.mu held.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, f.Path) {
+			continue
+		}
+		f.Count--
+		if f.Count == 0 {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+		}
+		return f
+	}
+	return nil
+}
+
+func (s *Server) handleOAuthToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if r.PostForm.Get("grant_type") != "client_credentials" ||
+		r.PostForm.Get("client_id") != ClientID ||
+		r.PostForm.Get("client_secret") != ClientSecret {
+		writeError(w, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+	token, lifetime := s.issueToken()
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": token,
+		"scope":        "api-role:1",
+		"token_type":   "Bearer",
+		"expires_in":   int(lifetime.Seconds()),
+	})
+}
+
+func (s *Server) handleAuthToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	username, password, ok := r.BasicAuth()
+	if !ok || username != Username || password != Password {
+		writeError(w, http.StatusUnauthorized, "invalid credentials")
+		return
+	}
+	s.writeAuthToken(w)
+}
+
+func (s *Server) handleKeepAlive(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	s.invalidate(r)
+	s.writeAuthToken(w)
+}
+
+func (s *Server) handleInvalidateToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	s.invalidate(r)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeAuthToken issues a token in the format of the /api/v1/auth endpoints.
+func (s *Server) writeAuthToken(w http.ResponseWriter) {
+	token, lifetime := s.issueToken()
+	writeJSON(w, http.StatusOK, map[string]any{
+		"token":   token,
+		"expires": time.Now().Add(lifetime).UTC().Format("2006-01-02T15:04:05.000Z"),
+	})
+}
+
+// invalidate revokes the bearer token a request was made with.
+func (s *Server) invalidate(r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, ok := s.tokens[token]; ok {
+		t.invalidated = true
+		s.tokens[token] = t
+	}
+}
+
+func (s *Server) issueToken() (string, time.Duration) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("jamfmock: cannot generate token: %s", err))
+	}
+	token := hex.EncodeToString(b)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.tokens[token] = issuedToken{issued: now, expires: now.Add(s.tokenLifetime)}
+	return token, s.tokenLifetime
+}
+
+// tokenValid reports whether token may be used to authenticate a request.
+// Tokens not issued by this server are accepted until RevokeTokens is called,
+// so that sessions cached by a previous test run do not need to be cleared.
+func (s *Server) tokenValid(token string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, issued := s.tokens[token]
+	if !issued {
+		return s.revokedBefore.IsZero()
+	}
+	return !t.invalidated && time.Now().Before(t.expires) && t.issued.After(s.revokedBefore)
+}
+
+// authenticated rejects requests without a valid bearer token.
+func (s *Server) authenticated(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			writeError(w, http.StatusUnauthorized, "missing bearer token")
+			return
+		}
+		if !s.tokenValid(token) {
+			writeError(w, http.StatusUnauthorized, "invalid bearer token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
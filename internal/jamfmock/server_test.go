@@ -0,0 +1,359 @@
+package jamfmock
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func token(t *testing.T, s *Server) string {
+	t.Helper()
+	resp, err := http.PostForm(s.URL+"/api/oauth/token", url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {ClientID},
+		"client_secret": {ClientSecret},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("token request returned %d", resp.StatusCode)
+	}
+	var body struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	return body.AccessToken
+}
+
+func do(t *testing.T, s *Server, token, method, path, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, s.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestServerAuthentication(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	if resp := do(t, s, "", http.MethodGet, "/api/v1/categories", ""); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("request without token returned %d", resp.StatusCode)
+	}
+	tok := token(t, s)
+	if resp := do(t, s, tok, http.MethodGet, "/api/v1/categories", ""); resp.StatusCode != http.StatusOK {
+		t.Errorf("request with token returned %d", resp.StatusCode)
+	}
+	s.RevokeTokens()
+	if resp := do(t, s, tok, http.MethodGet, "/api/v1/categories", ""); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("request with revoked token returned %d", resp.StatusCode)
+	}
+	if resp := do(t, s, token(t, s), http.MethodGet, "/api/v1/categories", ""); resp.StatusCode != http.StatusOK {
+		t.Errorf("request with new token returned %d", resp.StatusCode)
+	}
+}
+
+func TestServerFaults(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	tok := token(t, s)
+
+	s.InjectFault(Fault{Method: http.MethodGet, Path: "/api/v1/buildings", Status: http.StatusTooManyRequests, Count: 2, RetryAfter: 3 * time.Second})
+	for i := 0; i < 2; i++ {
+		resp := do(t, s, tok, http.MethodGet, "/api/v1/buildings", "")
+		if resp.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("request %d returned %d", i, resp.StatusCode)
+		}
+		if got := resp.Header.Get("Retry-After"); got != "3" {
+			t.Errorf("Retry-After = %q", got)
+		}
+	}
+	if resp := do(t, s, tok, http.MethodGet, "/api/v1/buildings", ""); resp.StatusCode != http.StatusOK {
+		t.Errorf("request after fault returned %d", resp.StatusCode)
+	}
+}
+
+func TestServerPropagationDelay(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	tok := token(t, s)
+	s.SetPropagationDelay(2)
+
+	resp := do(t, s, tok, http.MethodPost, "/api/v1/departments", `{"name": "IT"}`)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("create returned %d", resp.StatusCode)
+	}
+	for _, want := range []int{http.StatusNotFound, http.StatusNotFound, http.StatusOK} {
+		if got := do(t, s, tok, http.MethodGet, "/api/v1/departments/1", "").StatusCode; got != want {
+			t.Errorf("read returned %d, want %d", got, want)
+		}
+	}
+
+	do(t, s, tok, http.MethodPut, "/api/v1/departments/1", `{"name": "Engineering"}`)
+	for _, want := range []string{"IT", "IT", "Engineering"} {
+		var department map[string]any
+		if err := json.NewDecoder(do(t, s, tok, http.MethodGet, "/api/v1/departments/1", "").Body).Decode(&department); err != nil {
+			t.Fatal(err)
+		}
+		if department["name"] != want {
+			t.Errorf("read returned name %v, want %s", department["name"], want)
+		}
+	}
+}
+
+func TestServerListFilterAndPaging(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	tok := token(t, s)
+
+	for _, name := range []string{"LAB-2", "Office", "LAB-1", "LAB-3"} {
+		do(t, s, tok, http.MethodPost, "/api/v1/categories", `{"name": "`+name+`"}`)
+	}
+
+	var page struct {
+		TotalCount int              `json:"totalCount"`
+		Results    []map[string]any `json:"results"`
+	}
+	query := url.Values{"filter": {`name=="LAB-*"`}, "sort": {"name:desc"}, "page": {"1"}, "page-size": {"2"}}
+	if err := json.NewDecoder(do(t, s, tok, http.MethodGet, "/api/v1/categories?"+query.Encode(), "").Body).Decode(&page); err != nil {
+		t.Fatal(err)
+	}
+	if page.TotalCount != 3 {
+		t.Errorf("totalCount = %d, want 3", page.TotalCount)
+	}
+	if len(page.Results) != 1 || page.Results[0]["name"] != "LAB-1" {
+		t.Errorf("results = %v, want LAB-1 only", page.Results)
+	}
+}
+
+func TestServerApiRolePrivileges(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	tok := token(t, s)
+
+	var body struct {
+		Privileges []string `json:"privileges"`
+	}
+	if err := json.NewDecoder(do(t, s, tok, http.MethodGet, "/api/v1/api-role-privileges", "").Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Privileges) != len(ApiRolePrivileges) {
+		t.Errorf("got %d privileges, want %d", len(body.Privileges), len(ApiRolePrivileges))
+	}
+
+	if resp := do(t, s, tok, http.MethodPost, "/api/v1/api-roles", `{"displayName": "Valid", "privileges": ["Read Computers"]}`); resp.StatusCode != http.StatusCreated {
+		t.Errorf("API role create returned %d", resp.StatusCode)
+	}
+	if resp := do(t, s, tok, http.MethodPost, "/api/v1/api-roles", `{"displayName": "Invalid", "privileges": ["Read Computer"]}`); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("API role create with an unknown privilege returned %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestServerApiIntegrations(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	tok := token(t, s)
+
+	do(t, s, tok, http.MethodPost, "/api/v1/api-roles", `{"displayName": "Reader", "privileges": ["Read Computers"]}`)
+	if resp := do(t, s, tok, http.MethodPost, "/api/v1/api-integrations", `{"displayName": "Invalid", "authorizationScopes": ["Writer"]}`); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("API integration create with an unknown API role returned %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+
+	var integration struct {
+		Id       string `json:"id"`
+		Enabled  bool   `json:"enabled"`
+		ClientId string `json:"clientId"`
+	}
+	resp := do(t, s, tok, http.MethodPost, "/api/v1/api-integrations", `{"displayName": "Automation", "authorizationScopes": ["Reader"]}`)
+	if err := json.NewDecoder(resp.Body).Decode(&integration); err != nil {
+		t.Fatal(err)
+	}
+	if !integration.Enabled || integration.ClientId == "" {
+		t.Errorf("unexpected API integration %+v", integration)
+	}
+
+	var credentials struct {
+		ClientId     string `json:"clientId"`
+		ClientSecret string `json:"clientSecret"`
+	}
+	resp = do(t, s, tok, http.MethodPost, "/api/v1/api-integrations/"+integration.Id+"/client-credentials", "")
+	if err := json.NewDecoder(resp.Body).Decode(&credentials); err != nil {
+		t.Fatal(err)
+	}
+	if credentials.ClientId != integration.ClientId || credentials.ClientSecret == "" {
+		t.Errorf("unexpected client credentials %+v", credentials)
+	}
+	if resp := do(t, s, tok, http.MethodPost, "/api/v1/api-integrations/99/client-credentials", ""); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("client credentials of a missing API integration returned %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestServerClassicGroups(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	tok := token(t, s)
+
+	req, _ := http.NewRequest(http.MethodPost, s.URL+"/JSSResource/computers/id/0",
+		strings.NewReader(`<computer><general><name>Mac</name><serial_number>C02ABC</serial_number></general></computer>`))
+	req.Header.Set("Authorization", "Bearer "+tok)
+	req.Header.Set("Content-Type", "application/xml")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("computer create returned %d", resp.StatusCode)
+	}
+
+	resp = do(t, s, tok, http.MethodPost, "/JSSResource/computergroups/id/0",
+		`{"computer_group": {"name": "Static", "computers": [{"serial_number": "C02ABC"}]}}`)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("group create returned %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Group classicGroup `json:"computer_group"`
+	}
+	if err := json.NewDecoder(do(t, s, tok, http.MethodGet, "/JSSResource/computergroups/name/Static", "").Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Group.IsSmart || len(body.Group.Computers) != 1 || body.Group.Computers[0].Name != "Mac" {
+		t.Errorf("unexpected group %+v", body.Group)
+	}
+
+	resp = do(t, s, tok, http.MethodPut, "/JSSResource/computergroups/name/Static",
+		`{"computer_group": {"computer_deletions": [{"serial_number": "C02ABC"}]}}`)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("group member deletion returned %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(do(t, s, tok, http.MethodGet, "/JSSResource/computergroups/name/Static", "").Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Group.Name != "Static" || len(body.Group.Computers) != 0 {
+		t.Errorf("unexpected group after deletion %+v", body.Group)
+	}
+
+	resp = do(t, s, tok, http.MethodPut, "/JSSResource/computergroups/name/Static",
+		`{"computer_group": {"computer_additions": [{"name": "Mac"}, {"name": "Mac"}]}}`)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("group member addition returned %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(do(t, s, tok, http.MethodGet, "/JSSResource/computergroups/name/Static", "").Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Group.Computers) != 1 || body.Group.Computers[0].SerialNumber != "C02ABC" {
+		t.Errorf("unexpected group after addition %+v", body.Group)
+	}
+
+	resp = do(t, s, tok, http.MethodPost, "/JSSResource/computergroups/id/0",
+		`{"computer_group": {"name": "Smart", "is_smart": true, "criteria": [
+			{"name": "Computer Name", "priority": 0, "and_or": "and", "search_type": "like", "value": "ma"},
+			{"name": "Serial Number", "priority": 1, "and_or": "and", "search_type": "is not", "value": "C02ABC"}
+		]}}`)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("smart group create returned %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(do(t, s, tok, http.MethodGet, "/JSSResource/computergroups/name/Smart", "").Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if !body.Group.IsSmart || len(body.Group.Computers) != 0 {
+		t.Errorf("unexpected smart group %+v", body.Group)
+	}
+
+	resp = do(t, s, tok, http.MethodPut, "/JSSResource/computergroups/name/Smart",
+		`{"computer_group": {"name": "Smart", "is_smart": true, "criteria": [
+			{"name": "Computer Name", "priority": 0, "and_or": "and", "search_type": "like", "value": "ma"}
+		]}}`)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("smart group update returned %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(do(t, s, tok, http.MethodGet, "/JSSResource/computergroups/name/Smart", "").Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Group.Computers) != 1 || body.Group.Computers[0].SerialNumber != "C02ABC" {
+		t.Errorf("unexpected smart group members %+v", body.Group.Computers)
+	}
+	smartID := body.Group.ID
+
+	// Updates keep the fields they leave out
+	resp = do(t, s, tok, http.MethodPut, "/JSSResource/computergroups/name/Smart",
+		`{"computer_group": {"name": "Smart", "is_smart": false}}`)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("smart group rename returned %d", resp.StatusCode)
+	}
+	body.Group = classicGroup{}
+	if err := json.NewDecoder(do(t, s, tok, http.MethodGet, "/JSSResource/computergroups/name/Smart", "").Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if !body.Group.IsSmart || len(body.Group.Criteria) != 1 {
+		t.Errorf("update without criteria changed smart group %+v", body.Group)
+	}
+
+	// Updating a smart group with empty criteria converts it to a static group
+	resp = do(t, s, tok, http.MethodPut, "/JSSResource/computergroups/name/Smart",
+		`{"computer_group": {"name": "Smart", "is_smart": false, "criteria": [], "computers": [{"serial_number": "C02ABC"}]}}`)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("smart group conversion returned %d", resp.StatusCode)
+	}
+	body.Group = classicGroup{}
+	if err := json.NewDecoder(do(t, s, tok, http.MethodGet, "/JSSResource/computergroups/name/Smart", "").Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Group.ID != smartID || body.Group.IsSmart || len(body.Group.Criteria) != 0 || len(body.Group.Computers) != 1 {
+		t.Errorf("unexpected converted group %+v", body.Group)
+	}
+}
+
+func TestServerComputersInventory(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	tok := token(t, s)
+
+	for _, computer := range []string{`{"computer": {"general": {"name": "LAB-1", "serial_number": "C02AAA"}}}`, `{"computer": {"general": {"name": "Office", "serial_number": "C02BBB"}}}`} {
+		if resp := do(t, s, tok, http.MethodPost, "/JSSResource/computers/id/0", computer); resp.StatusCode != http.StatusCreated {
+			t.Fatalf("computer create returned %d", resp.StatusCode)
+		}
+	}
+
+	var page struct {
+		TotalCount int `json:"totalCount"`
+		Results    []struct {
+			Id       string `json:"id"`
+			Hardware struct {
+				SerialNumber string `json:"serialNumber"`
+			} `json:"hardware"`
+		} `json:"results"`
+	}
+	query := url.Values{"filter": {`general.name=="lab-*"`}, "section": {"GENERAL", "HARDWARE"}}
+	if err := json.NewDecoder(do(t, s, tok, http.MethodGet, "/api/v1/computers-inventory?"+query.Encode(), "").Body).Decode(&page); err != nil {
+		t.Fatal(err)
+	}
+	if page.TotalCount != 1 || page.Results[0].Id != "1" || page.Results[0].Hardware.SerialNumber != "C02AAA" {
+		t.Errorf("unexpected page %+v", page)
+	}
+
+	var computer map[string]any
+	if err := json.NewDecoder(do(t, s, tok, http.MethodGet, "/api/v1/computers-inventory/2?section=OPERATING_SYSTEM", "").Body).Decode(&computer); err != nil {
+		t.Fatal(err)
+	}
+	if computer["general"] != nil || computer["operatingSystem"] == nil {
+		t.Errorf("unexpected sections in %v", computer)
+	}
+}
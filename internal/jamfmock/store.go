@@ -0,0 +1,150 @@
+package jamfmock
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// record is a stored object. While staleReads is positive, reads are served
+// the previous version of the object, or nothing if it was just created.
+type record[T any] struct {
+	current    T
+	previous   *T
+	staleReads int
+}
+
+// store keeps the objects of one type, keyed by ID. It is guarded by Server.mu.
+type store[T any] struct {
+	nextID  int
+	records map[int]*record[T]
+}
+
+func newStore[T any]() *store[T] {
+	return &store[T]{
+		nextID:  1,
+		records: make(map[int]*record[T]),
+	}
+}
+
+// create stores the object returned by build for a newly allocated ID.
+func (st *store[T]) create(staleReads int, build func(id int) T) T {
+	id := st.nextID
+	st.nextID++
+	v := build(id)
+	st.records[id] = &record[T]{current: v, staleReads: staleReads}
+	return v
+}
+
+// get returns the object with the given ID as seen by a reader.
+func (st *store[T]) get(id int) (T, bool) {
+	var zero T
+	r, ok := st.records[id]
+	if !ok {
+		return zero, false
+	}
+	if r.staleReads > 0 {
+		r.staleReads--
+		if r.previous == nil {
+			return zero, false
+		}
+		return *r.previous, true
+	}
+	return r.current, true
+}
+
+// latest returns the object with the given ID, ignoring pending propagation.
+func (st *store[T]) latest(id int) (T, bool) {
+	var zero T
+	r, ok := st.records[id]
+	if !ok {
+		return zero, false
+	}
+	return r.current, true
+}
+
+func (st *store[T]) update(id int, staleReads int, v T) bool {
+	r, ok := st.records[id]
+	if !ok {
+		return false
+	}
+	previous := r.current
+	if r.staleReads > 0 && r.previous != nil {
+		previous = *r.previous
+	}
+	r.previous = &previous
+	r.current = v
+	r.staleReads = staleReads
+	return true
+}
+
+func (st *store[T]) delete(id int) bool {
+	if _, ok := st.records[id]; !ok {
+		return false
+	}
+	delete(st.records, id)
+	return true
+}
+
+// find returns the ID of the first object, in ID order, matching the predicate.
+func (st *store[T]) find(match func(T) bool) (int, bool) {
+	for _, id := range st.ids() {
+		if match(st.records[id].current) {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// list returns the objects that have propagated, in ID order.
+func (st *store[T]) list() []T {
+	values := make([]T, 0, len(st.records))
+	for _, id := range st.ids() {
+		r := st.records[id]
+		if r.staleReads > 0 {
+			if r.previous != nil {
+				values = append(values, *r.previous)
+			}
+			continue
+		}
+		values = append(values, r.current)
+	}
+	return values
+}
+
+func (st *store[T]) ids() []int {
+	ids := make([]int, 0, len(st.records))
+	for id := range st.records {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeXML(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(xml.Header))
+	_ = xml.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error in the format used by the Pro API.
+func writeError(w http.ResponseWriter, status int, description string) {
+	writeJSON(w, status, map[string]any{
+		"httpStatus": status,
+		"errors": []map[string]any{
+			{
+				"code":        strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_")),
+				"description": description,
+			},
+		},
+	})
+}
@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// apiClient sends requests to the Jamf Pro API endpoints that jamfpro.Client
+// does not cover. Authentication, retries and request limits are handled by
+// its transport, like for jamfpro.Client.
+type apiClient struct {
+	baseURL    string
+	httpClient *http.Client
+	userAgent  string
+
+	// privileges caches the API role privileges of the instance, which every
+	// jamfpro_api_role validates its privileges against.
+	privilegesMu sync.Mutex
+	privileges   []string
+}
+
+func newAPIClient(instanceURL string, transport http.RoundTripper, userAgent string) *apiClient {
+	return &apiClient{
+		baseURL:    normalizeInstanceURL(instanceURL),
+		httpClient: &http.Client{Transport: transport},
+		userAgent:  userAgent,
+	}
+}
+
+// apiError is returned for a response with an unexpected status.
+type apiError struct {
+	method     string
+	path       string
+	statusCode int
+	body       []byte
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s %s returned status %d: %s", e.method, e.path, e.statusCode, e.body)
+}
+
+// isNotFoundError reports whether err is the response of Jamf Pro that an object does not exist.
+func isNotFoundError(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.statusCode == http.StatusNotFound
+}
+
+// getJSON decodes the JSON response to a GET request of path into v.
+func (c *apiClient) getJSON(ctx context.Context, path string, query url.Values, v any) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("User-Agent", c.userAgent)
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return &apiError{method: http.MethodGet, path: path, statusCode: response.StatusCode, body: body}
+	}
+	if err := json.NewDecoder(response.Body).Decode(v); err != nil {
+		return fmt.Errorf("unable to decode response to GET %s: %w", path, err)
+	}
+	return nil
+}
+
+// putXML sends v as the XML body of a PUT request of path, such as those of the Classic API.
+func (c *apiClient) putXML(ctx context.Context, path string, v any) error {
+	body, err := xml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/xml")
+	request.Header.Set("Accept", "application/xml")
+	request.Header.Set("User-Agent", c.userAgent)
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return &apiError{method: http.MethodPut, path: path, statusCode: response.StatusCode, body: body}
+	}
+	return nil
+}
+
+// sendJSON sends body as the JSON body of a request of path with the given
+// method, decoding the JSON response into v unless v is nil.
+func (c *apiClient) sendJSON(ctx context.Context, method string, path string, body any, v any) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("User-Agent", c.userAgent)
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return &apiError{method: method, path: path, statusCode: response.StatusCode, body: body}
+	}
+	if v == nil {
+		return nil
+	}
+	if err := json.NewDecoder(response.Body).Decode(v); err != nil {
+		return fmt.Errorf("unable to decode response to %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+// findIDsByName returns the IDs of the objects of a Jamf Pro API collection
+// (e.g. /api/v1/buildings) whose name, held by nameField, is name.
+func (c *apiClient) findIDsByName(ctx context.Context, collection string, nameField string, name string) ([]int, error) {
+	query := url.Values{
+		"filter":    {nameField + "==" + rsqlQuote(name)},
+		"page-size": {"100"},
+	}
+	var page struct {
+		Results []struct {
+			// Id is a string in most collections and a number in some, e.g. api-integrations.
+			Id json.Number `json:"id"`
+		} `json:"results"`
+	}
+	if err := c.getJSON(ctx, collection, query, &page); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(page.Results))
+	for _, result := range page.Results {
+		id, err := strconv.Atoi(result.Id.String())
+		if err != nil {
+			return nil, fmt.Errorf("invalid ID %q in response to GET %s", result.Id, collection)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// listPageSize is the number of objects requested per page of a collection.
+const listPageSize = 100
+
+// listAll decodes the objects of a Pro API collection selected by query,
+// following the pages of results to the last. Unless query sorts by ID, the
+// objects are sorted by ID after the requested sort, so that their order is
+// stable from one page, and one run, to the next.
+func listAll[T any](ctx context.Context, c *apiClient, collection string, query url.Values) ([]T, error) {
+	q := make(url.Values, len(query)+3)
+	for key, values := range query {
+		q[key] = append([]string(nil), values...)
+	}
+	sortedByID := false
+	for _, s := range q["sort"] {
+		for _, key := range strings.Split(s, ",") {
+			if field, _, _ := strings.Cut(key, ":"); field == "id" {
+				sortedByID = true
+			}
+		}
+	}
+	if !sortedByID {
+		q.Add("sort", "id:asc")
+	}
+	q.Set("page-size", strconv.Itoa(listPageSize))
+
+	objects := make([]T, 0)
+	for page := 0; ; page++ {
+		q.Set("page", strconv.Itoa(page))
+		var body struct {
+			TotalCount int `json:"totalCount"`
+			Results    []T `json:"results"`
+		}
+		if err := c.getJSON(ctx, collection, q, &body); err != nil {
+			return nil, err
+		}
+		objects = append(objects, body.Results...)
+		if len(body.Results) == 0 || len(objects) >= body.TotalCount {
+			return objects, nil
+		}
+	}
+}
+
+// rsqlQuote quotes a value for use in an RSQL filter.
+func rsqlQuote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jc0b/go-jamfpro-api/jamfpro"
+	"github.com/jc0b/terraform-provider-jamfpro/internal/jamfmock"
+)
+
+func TestAPIClientFindIDsByName(t *testing.T) {
+	server := jamfmock.NewServer()
+	defer server.Close()
+	client := testAuthClient(t, server)
+	api := newAPIClient(server.URL, client.Transport, "test")
+
+	for _, name := range []string{"Amsterdam", "Berlin", "Amsterdam Annex"} {
+		response, err := client.Post(server.URL+"/api/v1/buildings", "application/json", strings.NewReader(`{"name": "`+name+`"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusCreated {
+			t.Fatalf("creating %s returned %d", name, response.StatusCode)
+		}
+	}
+
+	for name, want := range map[string][]int{"Amsterdam": {1}, "Berlin": {2}, "Paris": {}} {
+		ids, err := api.findIDsByName(context.Background(), "/api/v1/buildings", "name", name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(ids, want) {
+			t.Errorf("findIDsByName(%q) = %v, want %v", name, ids, want)
+		}
+	}
+}
+
+func TestAPIClientSendJSON(t *testing.T) {
+	server := jamfmock.NewServer()
+	defer server.Close()
+	client := testAuthClient(t, server)
+	api := newAPIClient(server.URL, client.Transport, "test")
+	retry := newRetryPolicy(3, 10*time.Millisecond, nil)
+	ctx := context.Background()
+
+	if err := api.sendJSON(ctx, http.MethodPost, "/api/v1/api-roles", map[string]any{"displayName": "Reader", "privileges": []string{"Read Computers"}}, nil); err != nil {
+		t.Fatal(err)
+	}
+	var created apiIntegration
+	request := apiIntegration{DisplayName: "Automation", Enabled: true, AuthorizationScopes: []string{"Reader"}, AccessTokenLifetimeSeconds: 300}
+	if err := api.sendJSON(ctx, http.MethodPost, apiIntegrationsPath, request, &created); err != nil {
+		t.Fatal(err)
+	}
+	id, err := created.Id.Int64()
+	if err != nil || created.ClientId == "" {
+		t.Fatalf("unexpected API integration %+v", created)
+	}
+
+	read, found, err := readAPIObject[apiIntegration](ctx, retry, api, apiIntegrationPath(id))
+	if err != nil || !found {
+		t.Fatalf("readAPIObject = %t, %v", found, err)
+	}
+	if read.DisplayName != "Automation" || read.AccessTokenLifetimeSeconds != 300 || read.ClientId != created.ClientId {
+		t.Errorf("unexpected API integration %+v", read)
+	}
+	ids, err := api.findIDsByName(ctx, apiIntegrationsPath, "displayName", "Automation")
+	if err != nil || !reflect.DeepEqual(ids, []int{int(id)}) {
+		t.Errorf("findIDsByName = %v, %v", ids, err)
+	}
+
+	if err := api.sendJSON(ctx, http.MethodDelete, apiIntegrationPath(id), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, found, err := readAPIObject[apiIntegration](ctx, retry, api, apiIntegrationPath(id)); err != nil || found {
+		t.Errorf("readAPIObject after delete = %t, %v", found, err)
+	}
+	err = api.sendJSON(ctx, http.MethodDelete, apiIntegrationPath(id), nil, nil)
+	if !isNotFoundError(err) {
+		t.Errorf("deleting a missing API integration returned %v", err)
+	}
+}
+
+func TestListAll(t *testing.T) {
+	server := jamfmock.NewServer()
+	defer server.Close()
+	client := testAuthClient(t, server)
+	api := newAPIClient(server.URL, client.Transport, "test")
+
+	for i := 0; i < 2*listPageSize+10; i++ {
+		name := fmt.Sprintf("LAB-%03d", i)
+		if i%2 == 1 {
+			name = fmt.Sprintf("Office-%03d", i)
+		}
+		response, err := client.Post(server.URL+"/api/v1/departments", "application/json", strings.NewReader(`{"name": "`+name+`"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		response.Body.Close()
+	}
+
+	departments, err := listAll[jamfpro.Department](context.Background(), api, "/api/v1/departments", url.Values{
+		"filter": {`name=="Office-*"`},
+		"sort":   {"name:desc"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(departments) != listPageSize+5 {
+		t.Fatalf("listed %d departments, want %d", len(departments), listPageSize+5)
+	}
+	for i := 1; i < len(departments); i++ {
+		if departments[i-1].Name <= departments[i].Name {
+			t.Fatalf("departments %q and %q are not sorted by descending name", departments[i-1].Name, departments[i].Name)
+		}
+	}
+
+	pages := 0
+	for _, request := range server.Requests() {
+		if request.Method == http.MethodGet && request.Path == "/api/v1/departments" {
+			pages++
+		}
+	}
+	if pages != 2 {
+		t.Errorf("listed departments in %d requests, want 2", pages)
+	}
+}
+
+func TestRSQLQuote(t *testing.T) {
+	for value, want := range map[string]string{
+		`HQ`:         `"HQ"`,
+		`The "Dome"`: `"The \"Dome\""`,
+		`C:\`:        `"C:\\"`,
+	} {
+		if got := rsqlQuote(value); got != want {
+			t.Errorf("rsqlQuote(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
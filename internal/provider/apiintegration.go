@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"sort"
+)
+
+// apiIntegrationsPath is the Pro API collection of API integrations, the API
+// clients authenticating with client credentials.
+const apiIntegrationsPath = "/api/v1/api-integrations"
+
+type apiintegration struct {
+	Id                         types.Int64  `tfsdk:"id"`
+	DisplayName                types.String `tfsdk:"display_name"`
+	Enabled                    types.Bool   `tfsdk:"enabled"`
+	AuthorizationScopes        types.Set    `tfsdk:"authorization_scopes"`
+	AccessTokenLifetimeSeconds types.Int64  `tfsdk:"access_token_lifetime_seconds"`
+	ClientId                   types.String `tfsdk:"client_id"`
+}
+
+// apiIntegration is an API integration as served by the Pro API.
+type apiIntegration struct {
+	Id                         json.Number `json:"id,omitempty"`
+	DisplayName                string      `json:"displayName"`
+	Enabled                    bool        `json:"enabled"`
+	AuthorizationScopes        []string    `json:"authorizationScopes"`
+	AccessTokenLifetimeSeconds int64       `json:"accessTokenLifetimeSeconds"`
+	ClientId                   string      `json:"clientId,omitempty"`
+}
+
+// apiIntegrationCredentials are client credentials of an API integration.
+type apiIntegrationCredentials struct {
+	ClientId     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret"`
+}
+
+// apiIntegrationPath is the Pro API path of an API integration.
+func apiIntegrationPath(id int64) string {
+	return fmt.Sprintf("%s/%d", apiIntegrationsPath, id)
+}
+
+func apiIntegrationForState(a apiIntegration) (apiintegration, error) {
+	id, err := a.Id.Int64()
+	if err != nil {
+		return apiintegration{}, fmt.Errorf("invalid API integration ID %q", a.Id)
+	}
+
+	scopes := append([]string(nil), a.AuthorizationScopes...)
+	sort.Strings(scopes)
+	values := make([]attr.Value, 0, len(scopes))
+	for _, scope := range scopes {
+		values = append(values, types.StringValue(scope))
+	}
+
+	return apiintegration{
+		Id:                         types.Int64Value(id),
+		DisplayName:                types.StringValue(a.DisplayName),
+		Enabled:                    types.BoolValue(a.Enabled),
+		AuthorizationScopes:        types.SetValueMust(types.StringType, values),
+		AccessTokenLifetimeSeconds: types.Int64Value(a.AccessTokenLifetimeSeconds),
+		ClientId:                   types.StringValue(a.ClientId),
+	}, nil
+}
+
+// apiIntegrationRequestWithState converts the model of an API integration to
+// the body of a request creating or updating it.
+func apiIntegrationRequestWithState(data apiintegration) apiIntegration {
+	scopes := make([]string, 0)
+	for _, scope := range data.AuthorizationScopes.Elements() {
+		scopes = append(scopes, scope.(types.String).ValueString())
+	}
+	return apiIntegration{
+		DisplayName:                data.DisplayName.ValueString(),
+		Enabled:                    data.Enabled.ValueBool(),
+		AuthorizationScopes:        scopes,
+		AccessTokenLifetimeSeconds: data.AccessTokenLifetimeSeconds.ValueInt64(),
+	}
+}
@@ -1,9 +1,14 @@
 package provider
 
 import (
+	"context"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jc0b/go-jamfpro-api/jamfpro"
+	"sort"
 	"strconv"
 )
 
@@ -27,3 +32,52 @@ func apiRoleForState(a *jamfpro.ApiRole) apirole {
 		Privileges: types.SetValueMust(types.StringType, privileges),
 	}
 }
+
+// apiRolePrivilegesPath is the Pro API path of the privileges API roles can grant.
+const apiRolePrivilegesPath = "/api/v1/api-role-privileges"
+
+// apiRolePrivileges returns the privileges API roles can grant on the
+// instance, sorted. They are fetched once and cached for the other API roles.
+func (c *apiClient) apiRolePrivileges(ctx context.Context) ([]string, error) {
+	c.privilegesMu.Lock()
+	defer c.privilegesMu.Unlock()
+	if c.privileges != nil {
+		return c.privileges, nil
+	}
+
+	var body struct {
+		Privileges []string `json:"privileges"`
+	}
+	if err := c.getJSON(ctx, apiRolePrivilegesPath, nil, &body); err != nil {
+		return nil, err
+	}
+	privileges := append([]string{}, body.Privileges...)
+	sort.Strings(privileges)
+	c.privileges = privileges
+	return privileges, nil
+}
+
+// validateApiRolePrivileges reports the privileges that are not among those
+// API roles can grant, suggesting the nearest one if it is close enough to be
+// a typo. known must be sorted. Privileges are matched exactly, as Jamf Pro
+// does.
+func validateApiRolePrivileges(privileges []string, known []string, privilegesPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, privilege := range privileges {
+		if i := sort.SearchStrings(known, privilege); i < len(known) && known[i] == privilege {
+			continue
+		}
+		detail := fmt.Sprintf("%q is not a privilege API roles can grant in Jamf Pro.", privilege)
+		if match, ok := nearestMatch(privilege, known, 3); ok {
+			detail += fmt.Sprintf(" Did you mean %q?", match)
+		} else {
+			detail += " The jamfpro_api_role_privileges data source lists the privileges."
+		}
+		diags.AddAttributeError(
+			privilegesPath.AtSetValue(types.StringValue(privilege)),
+			"Unknown API role privilege",
+			detail,
+		)
+	}
+	return diags
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateApiRolePrivileges(t *testing.T) {
+	known := []string{"Read Buildings", "Read Computers", "Update Computers"}
+	privilegesPath := path.Root("privileges")
+
+	for name, tc := range map[string]struct {
+		privileges []string
+		// wantDetails are the details of the expected errors, in order.
+		wantDetails []string
+	}{
+		"valid": {
+			privileges: []string{"Read Computers", "Update Computers"},
+		},
+		"typo": {
+			privileges:  []string{"Read Buildings", "Read Computer"},
+			wantDetails: []string{`"Read Computer" is not a privilege API roles can grant in Jamf Pro. Did you mean "Read Computers"?`},
+		},
+		"case": {
+			privileges:  []string{"read computers"},
+			wantDetails: []string{`"read computers" is not a privilege API roles can grant in Jamf Pro. Did you mean "Read Computers"?`},
+		},
+		"unknown": {
+			privileges: []string{"Delete Everything"},
+			wantDetails: []string{`"Delete Everything" is not a privilege API roles can grant in Jamf Pro. ` +
+				`The jamfpro_api_role_privileges data source lists the privileges.`},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			errors := validateApiRolePrivileges(tc.privileges, known, privilegesPath).Errors()
+			if len(errors) != len(tc.wantDetails) {
+				t.Fatalf("got %d errors, want %d: %v", len(errors), len(tc.wantDetails), errors)
+			}
+			for i, d := range errors {
+				if d.Detail() != tc.wantDetails[i] {
+					t.Errorf("error %d has detail %q, want %q", i, d.Detail(), tc.wantDetails[i])
+				}
+				withPath, ok := d.(interface{ Path() path.Path })
+				want := privilegesPath.AtSetValue(types.StringValue(tc.privileges[len(tc.privileges)-1]))
+				if !ok || !withPath.Path().Equal(want) {
+					t.Errorf("error %d is not at %s", i, want)
+				}
+			}
+		})
+	}
+}
@@ -1,15 +1,27 @@
 package provider
 
 import (
+	"context"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jc0b/go-jamfpro-api/jamfpro"
+	"strings"
+	"time"
 )
 
 type computergroup struct {
-	Id        types.Int64  `tfsdk:"id"`
-	Name      types.String `tfsdk:"name"`
-	Computers types.Set    `tfsdk:"computers"`
+	Id            types.Int64  `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Computers     types.Set    `tfsdk:"computers"`
+	ComputerIds   types.Set    `tfsdk:"computer_ids"`
+	SerialNumbers types.Set    `tfsdk:"serial_numbers"`
+	Timeouts      types.Object `tfsdk:"timeouts"`
 }
 
 var computerAttrTypes = map[string]attr.Type{
@@ -20,8 +32,26 @@ var computerAttrTypes = map[string]attr.Type{
 }
 
 func computerGroupForState(c *jamfpro.ComputerGroup) computergroup {
-	computers := make([]attr.Value, 0)
+	ids := make([]attr.Value, 0, len(c.Computers))
+	serialNumbers := make([]attr.Value, 0, len(c.Computers))
 	for _, machine := range c.Computers {
+		ids = append(ids, types.Int64Value(int64(machine.Id)))
+		serialNumbers = append(serialNumbers, types.StringValue(machine.SerialNumber))
+	}
+	return computergroup{
+		Id:            types.Int64Value(int64(c.Id)),
+		Name:          types.StringValue(c.Name),
+		Computers:     computersForState(c.Computers),
+		ComputerIds:   types.SetValueMust(types.Int64Type, ids),
+		SerialNumbers: types.SetValueMust(types.StringType, serialNumbers),
+		Timeouts:      types.ObjectNull(timeoutsAttrTypes),
+	}
+}
+
+// computersForState converts the members of a computer group to a set of computerAttrTypes objects.
+func computersForState(members []jamfpro.Computer) types.Set {
+	computers := make([]attr.Value, 0)
+	for _, machine := range members {
 		computers = append(
 			computers,
 			types.ObjectValueMust(
@@ -35,30 +65,108 @@ func computerGroupForState(c *jamfpro.ComputerGroup) computergroup {
 			),
 		)
 	}
-	return computergroup{
-		Id:        types.Int64Value(int64(c.Id)),
-		Name:      types.StringValue(c.Name),
-		Computers: types.SetValueMust(types.ObjectType{AttrTypes: computerAttrTypes}, computers),
-	}
+	return types.SetValueMust(types.ObjectType{AttrTypes: computerAttrTypes}, computers)
 }
 
+// computerGroupRequestWithState converts the model of a static computer group
+// to a request. The members are taken from the first of computer_ids,
+// serial_numbers and computers that is known, which is the one configured as
+// the others are computed.
 func computerGroupRequestWithState(data computergroup) *jamfpro.ComputerGroupRequest {
 	computers := make([]jamfpro.Computer, 0)
-	for _, machine := range data.Computers.Elements() {
-		machineMap := machine.(types.Object).Attributes()
-		if machineMap != nil {
-			computers = append(
-				computers,
-				jamfpro.Computer{
-					Id:           int(machineMap["id"].(types.Int64).ValueInt64()),
-					Name:         machineMap["name"].(types.String).ValueString(),
-					SerialNumber: machineMap["serial_number"].(types.String).ValueString(),
-					Udid:         machineMap["udid"].(types.String).ValueString(),
-				})
+	switch {
+	case !data.ComputerIds.IsNull() && !data.ComputerIds.IsUnknown():
+		for _, id := range data.ComputerIds.Elements() {
+			computers = append(computers, jamfpro.Computer{Id: int(id.(types.Int64).ValueInt64())})
+		}
+	case !data.SerialNumbers.IsNull() && !data.SerialNumbers.IsUnknown():
+		for _, serialNumber := range data.SerialNumbers.Elements() {
+			computers = append(computers, jamfpro.Computer{SerialNumber: serialNumber.(types.String).ValueString()})
+		}
+	default:
+		for _, machine := range data.Computers.Elements() {
+			machineMap := machine.(types.Object).Attributes()
+			if machineMap != nil {
+				computers = append(
+					computers,
+					jamfpro.Computer{
+						Id:           int(machineMap["id"].(types.Int64).ValueInt64()),
+						Name:         machineMap["name"].(types.String).ValueString(),
+						SerialNumber: machineMap["serial_number"].(types.String).ValueString(),
+						Udid:         machineMap["udid"].(types.String).ValueString(),
+					})
+			}
 		}
 	}
+	// Jamf Pro keeps the fields an update leaves out, so is_smart and the
+	// criteria are always sent to turn a smart group into a static one.
 	return &jamfpro.ComputerGroupRequest{
 		Name:      data.Name.ValueString(),
+		IsSmart:   false,
 		Computers: computers,
+		Criteria:  []jamfpro.ComputerGroupCriteria{},
 	}
 }
+
+// computerGroupTimeoutError describes a change to a computer group that did
+// not propagate before the timeout of the operation, as a summary and detail.
+func computerGroupTimeoutError(kind string, change string, timeout time.Duration, expected, observed *jamfpro.ComputerGroup) (string, string) {
+	lastObserved := "the group was not found"
+	if observed != nil {
+		lastObserved = fmt.Sprintf("name %q, %d computers and %d criteria", observed.Name, len(observed.Computers), len(observed.Criteria))
+	}
+	return "Timeout waiting for computer group",
+		fmt.Sprintf("The %s of %s %q with ID %d did not propagate in Jamf Pro within %s. Last observed state: %s. "+
+			"Increase the timeout in the timeouts block if the instance is slow.",
+			change, kind, expected.Name, expected.Id, timeout, lastObserved)
+}
+
+// movedComputerGroup is what moving a computer group between
+// jamfpro_computergroup and jamfpro_smartcomputergroup keeps of its state. The
+// group is the same in Jamf Pro, and the next apply converts it in place.
+type movedComputerGroup struct {
+	Id       types.Int64
+	Name     types.String
+	Timeouts types.Object
+}
+
+// computerGroupStateMover returns a state mover of the computer groups of this
+// provider of type typeName, at the version of source, which sets the target
+// state with set. The hostname of the provider address is ignored, so that
+// mirrors of the registry are supported.
+func computerGroupStateMover(source schema.Schema, typeName string, set func(context.Context, movedComputerGroup, *tfsdk.State) diag.Diagnostics) resource.StateMover {
+	return resource.StateMover{
+		SourceSchema: &source,
+		StateMover: func(ctx context.Context, request resource.MoveStateRequest, response *resource.MoveStateResponse) {
+			if request.SourceTypeName != typeName || request.SourceSchemaVersion != source.Version ||
+				!strings.HasSuffix(request.SourceProviderAddress, "/jc0b/jamfpro") {
+				return
+			}
+			if request.SourceState == nil {
+				response.Diagnostics.AddError(
+					"Unable to Move Resource State",
+					fmt.Sprintf("The state of %s does not match version %d of its schema. "+
+						"Please report this issue to the provider developers.", typeName, source.Version),
+				)
+				return
+			}
+
+			var moved movedComputerGroup
+			response.Diagnostics.Append(request.SourceState.GetAttribute(ctx, path.Root("id"), &moved.Id)...)
+			response.Diagnostics.Append(request.SourceState.GetAttribute(ctx, path.Root("name"), &moved.Name)...)
+			response.Diagnostics.Append(request.SourceState.GetAttribute(ctx, path.Root("timeouts"), &moved.Timeouts)...)
+			if response.Diagnostics.HasError() {
+				return
+			}
+			response.Diagnostics.Append(set(ctx, moved, &response.TargetState)...)
+		},
+	}
+}
+
+// moveSmartComputerGroupState sets the state of a static group moved from a
+// smart group. It has no members, so the next apply sets those configured.
+func moveSmartComputerGroupState(ctx context.Context, moved movedComputerGroup, state *tfsdk.State) diag.Diagnostics {
+	target := computerGroupForState(&jamfpro.ComputerGroup{Id: int(moved.Id.ValueInt64()), Name: moved.Name.ValueString()})
+	target.Timeouts = moved.Timeouts
+	return state.Set(ctx, target)
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jc0b/go-jamfpro-api/jamfpro"
+	"sort"
+	"strconv"
+)
+
+type computergroupmembership struct {
+	Id              types.String `tfsdk:"id"`
+	ComputerGroupId types.Int64  `tfsdk:"computer_group_id"`
+	ComputerIds     types.Set    `tfsdk:"computer_ids"`
+}
+
+// computerGroupMembershipChange is the body of a Classic API request adding
+// computers to a static group and deleting computers from it, leaving its
+// other members as they are.
+type computerGroupMembershipChange struct {
+	XMLName   xml.Name                      `xml:"computer_group"`
+	Additions []computerGroupMembershipItem `xml:"computer_additions>computer,omitempty"`
+	Deletions []computerGroupMembershipItem `xml:"computer_deletions>computer,omitempty"`
+}
+
+type computerGroupMembershipItem struct {
+	Id int `xml:"id"`
+}
+
+// computerGroupMembershipPath is the Classic API path of a computer group.
+func computerGroupMembershipPath(groupId int64) string {
+	return fmt.Sprintf("/JSSResource/computergroups/id/%d", groupId)
+}
+
+// computerGroupMembershipChangeOf returns the change from the computers of
+// prior to those of planned. Either may be nil.
+func computerGroupMembershipChangeOf(prior, planned []int64) computerGroupMembershipChange {
+	var change computerGroupMembershipChange
+	for _, id := range planned {
+		if !containsID(prior, id) {
+			change.Additions = append(change.Additions, computerGroupMembershipItem{Id: int(id)})
+		}
+	}
+	for _, id := range prior {
+		if !containsID(planned, id) {
+			change.Deletions = append(change.Deletions, computerGroupMembershipItem{Id: int(id)})
+		}
+	}
+	return change
+}
+
+// hasMembers reports whether the computers added by the change are members of
+// group, and those deleted by it are not.
+func (c computerGroupMembershipChange) hasMembers(group *jamfpro.ComputerGroup) bool {
+	members := computerGroupMemberIDs(group)
+	for _, a := range c.Additions {
+		if !containsID(members, int64(a.Id)) {
+			return false
+		}
+	}
+	for _, d := range c.Deletions {
+		if containsID(members, int64(d.Id)) {
+			return false
+		}
+	}
+	return true
+}
+
+// computerGroupMemberIDs returns the IDs of the members of a computer group.
+func computerGroupMemberIDs(group *jamfpro.ComputerGroup) []int64 {
+	ids := make([]int64, 0, len(group.Computers))
+	for _, c := range group.Computers {
+		ids = append(ids, int64(c.Id))
+	}
+	return ids
+}
+
+// computerGroupMembershipForState converts the computers a membership manages
+// to its model, keeping only those that are still members of the group.
+func computerGroupMembershipForState(group *jamfpro.ComputerGroup, managed []int64) computergroupmembership {
+	members := computerGroupMemberIDs(group)
+	ids := make([]int64, 0, len(managed))
+	for _, id := range managed {
+		if containsID(members, id) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	values := make([]attr.Value, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, types.Int64Value(id))
+	}
+	return computergroupmembership{
+		Id:              types.StringValue(strconv.Itoa(group.Id)),
+		ComputerGroupId: types.Int64Value(int64(group.Id)),
+		ComputerIds:     types.SetValueMust(types.Int64Type, values),
+	}
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,293 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"sort"
+	"strconv"
+)
+
+// inventorySections are the sections of the computers-inventory endpoint, in
+// the order of the attributes holding them.
+var inventorySections = []string{
+	"GENERAL",
+	"HARDWARE",
+	"OPERATING_SYSTEM",
+	"USER_AND_LOCATION",
+	"EXTENSION_ATTRIBUTES",
+	"GROUP_MEMBERSHIPS",
+}
+
+type computerInventory struct {
+	Id                  types.Int64  `tfsdk:"id"`
+	Name                types.String `tfsdk:"name"`
+	SerialNumber        types.String `tfsdk:"serial_number"`
+	Udid                types.String `tfsdk:"udid"`
+	Sections            types.Set    `tfsdk:"sections"`
+	General             types.Object `tfsdk:"general"`
+	Hardware            types.Object `tfsdk:"hardware"`
+	OperatingSystem     types.Object `tfsdk:"operating_system"`
+	UserAndLocation     types.Object `tfsdk:"user_and_location"`
+	ExtensionAttributes types.List   `tfsdk:"extension_attributes"`
+	GroupMemberships    types.List   `tfsdk:"group_memberships"`
+}
+
+var inventoryGeneralAttrTypes = map[string]attr.Type{
+	"name":              types.StringType,
+	"last_ip_address":   types.StringType,
+	"last_reported_ip":  types.StringType,
+	"last_contact_time": types.StringType,
+	"report_date":       types.StringType,
+	"asset_tag":         types.StringType,
+	"platform":          types.StringType,
+	"supervised":        types.BoolType,
+	"mdm_capable":       types.BoolType,
+	"managed":           types.BoolType,
+}
+
+var inventoryHardwareAttrTypes = map[string]attr.Type{
+	"make":                   types.StringType,
+	"model":                  types.StringType,
+	"model_identifier":       types.StringType,
+	"serial_number":          types.StringType,
+	"processor_type":         types.StringType,
+	"processor_architecture": types.StringType,
+	"total_ram_megabytes":    types.Int64Type,
+	"mac_address":            types.StringType,
+	"apple_silicon":          types.BoolType,
+}
+
+var inventoryOperatingSystemAttrTypes = map[string]attr.Type{
+	"name":                       types.StringType,
+	"version":                    types.StringType,
+	"build":                      types.StringType,
+	"supplemental_build_version": types.StringType,
+	"file_vault2_status":         types.StringType,
+}
+
+var inventoryUserAndLocationAttrTypes = map[string]attr.Type{
+	"username":      types.StringType,
+	"realname":      types.StringType,
+	"email":         types.StringType,
+	"position":      types.StringType,
+	"phone":         types.StringType,
+	"department_id": types.Int64Type,
+	"building_id":   types.Int64Type,
+	"room":          types.StringType,
+}
+
+var inventoryExtensionAttributeAttrTypes = map[string]attr.Type{
+	"definition_id": types.Int64Type,
+	"name":          types.StringType,
+	"values":        types.ListType{ElemType: types.StringType},
+}
+
+var inventoryGroupMembershipAttrTypes = map[string]attr.Type{
+	"group_id":    types.Int64Type,
+	"group_name":  types.StringType,
+	"smart_group": types.BoolType,
+}
+
+// inventoryComputer is a computer as returned by the computers-inventory
+// endpoint. Sections that were not requested are nil.
+type inventoryComputer struct {
+	Id      string `json:"id"`
+	Udid    string `json:"udid"`
+	General *struct {
+		Name            string `json:"name"`
+		LastIpAddress   string `json:"lastIpAddress"`
+		LastReportedIp  string `json:"lastReportedIp"`
+		LastContactTime string `json:"lastContactTime"`
+		ReportDate      string `json:"reportDate"`
+		AssetTag        string `json:"assetTag"`
+		Platform        string `json:"platform"`
+		Supervised      bool   `json:"supervised"`
+		MdmCapable      struct {
+			Capable bool `json:"capable"`
+		} `json:"mdmCapable"`
+		RemoteManagement struct {
+			Managed bool `json:"managed"`
+		} `json:"remoteManagement"`
+	} `json:"general"`
+	Hardware *struct {
+		Make                  string `json:"make"`
+		Model                 string `json:"model"`
+		ModelIdentifier       string `json:"modelIdentifier"`
+		SerialNumber          string `json:"serialNumber"`
+		ProcessorType         string `json:"processorType"`
+		ProcessorArchitecture string `json:"processorArchitecture"`
+		TotalRamMegabytes     int64  `json:"totalRamMegabytes"`
+		MacAddress            string `json:"macAddress"`
+		AppleSilicon          bool   `json:"appleSilicon"`
+	} `json:"hardware"`
+	OperatingSystem *struct {
+		Name                     string `json:"name"`
+		Version                  string `json:"version"`
+		Build                    string `json:"build"`
+		SupplementalBuildVersion string `json:"supplementalBuildVersion"`
+		FileVault2Status         string `json:"fileVault2Status"`
+	} `json:"operatingSystem"`
+	UserAndLocation *struct {
+		Username     string  `json:"username"`
+		Realname     string  `json:"realname"`
+		Email        string  `json:"email"`
+		Position     string  `json:"position"`
+		Phone        string  `json:"phone"`
+		DepartmentId *string `json:"departmentId"`
+		BuildingId   *string `json:"buildingId"`
+		Room         string  `json:"room"`
+	} `json:"userAndLocation"`
+	ExtensionAttributes *[]struct {
+		DefinitionId string   `json:"definitionId"`
+		Name         string   `json:"name"`
+		Values       []string `json:"values"`
+	} `json:"extensionAttributes"`
+	GroupMemberships *[]struct {
+		GroupId    string `json:"groupId"`
+		GroupName  string `json:"groupName"`
+		SmartGroup bool   `json:"smartGroup"`
+	} `json:"groupMemberships"`
+}
+
+// computerInventoryForState converts a computer's inventory to its model. The
+// attributes of the sections that were not fetched are null, as are name and
+// serial_number when the GENERAL or HARDWARE section was not fetched.
+func computerInventoryForState(c *inventoryComputer, sections types.Set) (computerInventory, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	state := computerInventory{
+		Name:                types.StringNull(),
+		SerialNumber:        types.StringNull(),
+		Udid:                types.StringValue(c.Udid),
+		Sections:            sections,
+		General:             types.ObjectNull(inventoryGeneralAttrTypes),
+		Hardware:            types.ObjectNull(inventoryHardwareAttrTypes),
+		OperatingSystem:     types.ObjectNull(inventoryOperatingSystemAttrTypes),
+		UserAndLocation:     types.ObjectNull(inventoryUserAndLocationAttrTypes),
+		ExtensionAttributes: types.ListNull(types.ObjectType{AttrTypes: inventoryExtensionAttributeAttrTypes}),
+		GroupMemberships:    types.ListNull(types.ObjectType{AttrTypes: inventoryGroupMembershipAttrTypes}),
+	}
+
+	id, err := strconv.Atoi(c.Id)
+	if err != nil {
+		diags.AddError("Invalid computer", fmt.Sprintf("Jamf Pro returned a computer with the invalid ID %q.", c.Id))
+		return state, diags
+	}
+	state.Id = types.Int64Value(int64(id))
+
+	if g := c.General; g != nil {
+		state.Name = types.StringValue(g.Name)
+		state.General = types.ObjectValueMust(inventoryGeneralAttrTypes, map[string]attr.Value{
+			"name":              types.StringValue(g.Name),
+			"last_ip_address":   types.StringValue(g.LastIpAddress),
+			"last_reported_ip":  types.StringValue(g.LastReportedIp),
+			"last_contact_time": types.StringValue(g.LastContactTime),
+			"report_date":       types.StringValue(g.ReportDate),
+			"asset_tag":         types.StringValue(g.AssetTag),
+			"platform":          types.StringValue(g.Platform),
+			"supervised":        types.BoolValue(g.Supervised),
+			"mdm_capable":       types.BoolValue(g.MdmCapable.Capable),
+			"managed":           types.BoolValue(g.RemoteManagement.Managed),
+		})
+	}
+
+	if h := c.Hardware; h != nil {
+		state.SerialNumber = types.StringValue(h.SerialNumber)
+		state.Hardware = types.ObjectValueMust(inventoryHardwareAttrTypes, map[string]attr.Value{
+			"make":                   types.StringValue(h.Make),
+			"model":                  types.StringValue(h.Model),
+			"model_identifier":       types.StringValue(h.ModelIdentifier),
+			"serial_number":          types.StringValue(h.SerialNumber),
+			"processor_type":         types.StringValue(h.ProcessorType),
+			"processor_architecture": types.StringValue(h.ProcessorArchitecture),
+			"total_ram_megabytes":    types.Int64Value(h.TotalRamMegabytes),
+			"mac_address":            types.StringValue(h.MacAddress),
+			"apple_silicon":          types.BoolValue(h.AppleSilicon),
+		})
+	}
+
+	if o := c.OperatingSystem; o != nil {
+		state.OperatingSystem = types.ObjectValueMust(inventoryOperatingSystemAttrTypes, map[string]attr.Value{
+			"name":                       types.StringValue(o.Name),
+			"version":                    types.StringValue(o.Version),
+			"build":                      types.StringValue(o.Build),
+			"supplemental_build_version": types.StringValue(o.SupplementalBuildVersion),
+			"file_vault2_status":         types.StringValue(o.FileVault2Status),
+		})
+	}
+
+	if u := c.UserAndLocation; u != nil {
+		state.UserAndLocation = types.ObjectValueMust(inventoryUserAndLocationAttrTypes, map[string]attr.Value{
+			"username":      types.StringValue(u.Username),
+			"realname":      types.StringValue(u.Realname),
+			"email":         types.StringValue(u.Email),
+			"position":      types.StringValue(u.Position),
+			"phone":         types.StringValue(u.Phone),
+			"department_id": optionalID(u.DepartmentId),
+			"building_id":   optionalID(u.BuildingId),
+			"room":          types.StringValue(u.Room),
+		})
+	}
+
+	if c.ExtensionAttributes != nil {
+		extensionAttributes := *c.ExtensionAttributes
+		sort.SliceStable(extensionAttributes, func(i, j int) bool {
+			return lessID(extensionAttributes[i].DefinitionId, extensionAttributes[j].DefinitionId)
+		})
+		values := make([]attr.Value, 0, len(extensionAttributes))
+		for _, ea := range extensionAttributes {
+			eaValues := make([]attr.Value, 0, len(ea.Values))
+			for _, v := range ea.Values {
+				eaValues = append(eaValues, types.StringValue(v))
+			}
+			values = append(values, types.ObjectValueMust(inventoryExtensionAttributeAttrTypes, map[string]attr.Value{
+				"definition_id": optionalID(&ea.DefinitionId),
+				"name":          types.StringValue(ea.Name),
+				"values":        types.ListValueMust(types.StringType, eaValues),
+			}))
+		}
+		state.ExtensionAttributes = types.ListValueMust(types.ObjectType{AttrTypes: inventoryExtensionAttributeAttrTypes}, values)
+	}
+
+	if c.GroupMemberships != nil {
+		memberships := *c.GroupMemberships
+		sort.SliceStable(memberships, func(i, j int) bool {
+			return lessID(memberships[i].GroupId, memberships[j].GroupId)
+		})
+		values := make([]attr.Value, 0, len(memberships))
+		for _, m := range memberships {
+			values = append(values, types.ObjectValueMust(inventoryGroupMembershipAttrTypes, map[string]attr.Value{
+				"group_id":    optionalID(&m.GroupId),
+				"group_name":  types.StringValue(m.GroupName),
+				"smart_group": types.BoolValue(m.SmartGroup),
+			}))
+		}
+		state.GroupMemberships = types.ListValueMust(types.ObjectType{AttrTypes: inventoryGroupMembershipAttrTypes}, values)
+	}
+
+	return state, diags
+}
+
+// optionalID converts an ID returned by the Pro API as a string to a number,
+// which is null when the ID is missing or not a number.
+func optionalID(id *string) types.Int64 {
+	if id == nil {
+		return types.Int64Null()
+	}
+	n, err := strconv.ParseInt(*id, 10, 64)
+	if err != nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(n)
+}
+
+// lessID orders IDs returned by the Pro API as strings numerically.
+func lessID(a, b string) bool {
+	m, errA := strconv.Atoi(a)
+	n, errB := strconv.Atoi(b)
+	if errA != nil || errB != nil {
+		return a < b
+	}
+	return m < n
+}
@@ -0,0 +1,400 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// computerCriteriaNames are the names of the built-in inventory criteria of
+// computer smart groups in Jamf Pro. Extension attributes can be used as
+// criteria as well, by their display name.
+var computerCriteriaNames = []string{
+	"Active Directory Status",
+	"Activation Lock Enabled",
+	"Apple Silicon",
+	"AppleCare ID",
+	"Application Bundle ID",
+	"Application Title",
+	"Application Version",
+	"Architecture Type",
+	"Asset Tag",
+	"Available SWUs",
+	"Bar Code",
+	"Battery Capacity",
+	"Boot Drive Available MB",
+	"Boot Drive Percentage Full",
+	"Boot ROM",
+	"Bootstrap Token Allowed",
+	"Bootstrap Token Escrowed",
+	"Building",
+	"Bus Speed MHz",
+	"Cache Size KB",
+	"Cached Packages",
+	"Computer Group",
+	"Computer Name",
+	"Department",
+	"Disk Encryption Configuration",
+	"Drive Capacity MB",
+	"Email Address",
+	"Enrolled via Automated Device Enrollment",
+	"External Boot Level",
+	"FileVault 2 Individual Key Validation",
+	"FileVault 2 Institutional Key",
+	"FileVault 2 Partition Encryption State",
+	"FileVault 2 Status",
+	"FileVault Status",
+	"Firewall Enabled",
+	"Font Title",
+	"Full Name",
+	"Gatekeeper",
+	"IP Address",
+	"Jamf Binary Version",
+	"Last Check-in",
+	"Last Enrollment",
+	"Last Inventory Update",
+	"Last Reported IP Address",
+	"Lease Expiration",
+	"Licensed Software",
+	"Life Expectancy",
+	"Local User Accounts",
+	"MAC Address",
+	"Mac App Store Applications",
+	"Make",
+	"Managed",
+	"Managed By",
+	"MDM Capability",
+	"Model",
+	"Model Identifier",
+	"Network Adapter Type",
+	"NIC Speed",
+	"Number of Available Updates",
+	"Number of Cores",
+	"Number of Processors",
+	"Operating System",
+	"Operating System Build",
+	"Operating System Name",
+	"Operating System Rapid Security Response",
+	"Operating System Version",
+	"Optical Drive",
+	"Packages Installed By Casper",
+	"Packages Installed By Installer.app/SWU",
+	"Phone Number",
+	"Platform",
+	"Plug-in Title",
+	"PO Date",
+	"PO Number",
+	"Position",
+	"Printer",
+	"Processor Speed MHz",
+	"Processor Type",
+	"Profile Identifier",
+	"Profile Name",
+	"Purchase Price",
+	"Purchased or Leased",
+	"Purchasing Account",
+	"Purchasing Contact",
+	"Recovery Lock Enabled",
+	"Remote Management",
+	"Room",
+	"Secure Boot Level",
+	"Serial Number",
+	"Services",
+	"Site",
+	"SMC Version",
+	"Supervised",
+	"System Integrity Protection",
+	"Total RAM MB",
+	"UDID",
+	"User Approved MDM",
+	"Username",
+	"Vendor",
+	"Warranty Expiration",
+}
+
+// searchTypes are the operators criteria compare inventory values with, along
+// with the validation of the values they compare against, if any.
+var searchTypes = []struct {
+	name          string
+	validateValue func(value string) error
+}{
+	{name: "is"},
+	{name: "is not"},
+	{name: "has"},
+	{name: "does not have"},
+	{name: "like"},
+	{name: "not like"},
+	{name: "matches regex", validateValue: validateRegexValue},
+	{name: "does not match regex", validateValue: validateRegexValue},
+	{name: "greater than", validateValue: validateNumericValue},
+	{name: "less than", validateValue: validateNumericValue},
+	{name: "greater than or equal", validateValue: validateNumericValue},
+	{name: "less than or equal", validateValue: validateNumericValue},
+	{name: "more than x days ago", validateValue: validateDaysValue},
+	{name: "less than x days ago", validateValue: validateDaysValue},
+	{name: "before (yyyy-mm-dd)", validateValue: validateDateValue},
+	{name: "after (yyyy-mm-dd)", validateValue: validateDateValue},
+	{name: "member of", validateValue: validateGroupValue},
+	{name: "not member of", validateValue: validateGroupValue},
+}
+
+// searchTypeNames returns the names of the search types, in the order they are documented.
+func searchTypeNames() []string {
+	names := make([]string, 0, len(searchTypes))
+	for _, t := range searchTypes {
+		names = append(names, t.name)
+	}
+	return names
+}
+
+// searchTypesMarkdown lists the search types for the documentation.
+func searchTypesMarkdown() string {
+	names := searchTypeNames()
+	for i, name := range names {
+		names[i] = "`" + name + "`"
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// versionPattern matches the numbers and dotted versions, such as those of
+// operating systems, that Jamf Pro compares numerically.
+var versionPattern = regexp.MustCompile(`^\d+(\.\d+)*$`)
+
+func validateNumericValue(value string) error {
+	if !versionPattern.MatchString(value) {
+		return fmt.Errorf("%q is not a number or version", value)
+	}
+	return nil
+}
+
+func validateDaysValue(value string) error {
+	if _, err := strconv.ParseUint(value, 10, 32); err != nil {
+		return fmt.Errorf("%q is not a whole number of days", value)
+	}
+	return nil
+}
+
+func validateDateValue(value string) error {
+	if _, err := time.Parse("2006-01-02", value); err != nil {
+		return fmt.Errorf("%q is not a date of the form yyyy-mm-dd", value)
+	}
+	return nil
+}
+
+func validateRegexValue(value string) error {
+	if _, err := regexp.Compile(value); err != nil {
+		return fmt.Errorf("%q is not a valid regular expression: %s", value, err)
+	}
+	return nil
+}
+
+func validateGroupValue(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("the name of a group is required")
+	}
+	return nil
+}
+
+// criterion is the model of a single criterion of a smart group.
+type criterion struct {
+	Name         types.String `tfsdk:"name"`
+	Priority     types.Int64  `tfsdk:"priority"`
+	AndOr        types.String `tfsdk:"and_or"`
+	SearchType   types.String `tfsdk:"search_type"`
+	Value        types.String `tfsdk:"value"`
+	OpeningParen types.Bool   `tfsdk:"opening_paren"`
+	ClosingParen types.Bool   `tfsdk:"closing_paren"`
+}
+
+// criteriaExpression is a node of the expression formed by the criteria of a
+// smart group. A node is either a single criterion, or a group of nodes
+// between parentheses. The root is the group of all criteria.
+type criteriaExpression struct {
+	// index is the position of the criterion in the list, or of the criterion
+	// opening the group.
+	index int
+	// group is true for the root and the nodes between parentheses.
+	group    bool
+	children []*criteriaExpression
+}
+
+// parseCriteria parses criteria into an expression, reporting unbalanced
+// parentheses at the attributes causing them. Criteria with unknown
+// parentheses are taken to have none.
+func parseCriteria(criteria []criterion, criteriaPath path.Path) (*criteriaExpression, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	root := &criteriaExpression{index: -1, group: true}
+	open := []*criteriaExpression{root}
+
+	for i, c := range criteria {
+		if c.OpeningParen.ValueBool() {
+			group := &criteriaExpression{index: i, group: true}
+			top := open[len(open)-1]
+			top.children = append(top.children, group)
+			open = append(open, group)
+		}
+
+		top := open[len(open)-1]
+		top.children = append(top.children, &criteriaExpression{index: i})
+
+		if c.ClosingParen.ValueBool() {
+			if len(open) == 1 {
+				diags.AddAttributeError(
+					criteriaPath.AtListIndex(i).AtName("closing_paren"),
+					"Unbalanced parentheses",
+					fmt.Sprintf("Criteria %d closes a parenthesis that no criteria before it opens.", i),
+				)
+				continue
+			}
+			open = open[:len(open)-1]
+		}
+	}
+
+	for _, group := range open[1:] {
+		diags.AddAttributeError(
+			criteriaPath.AtListIndex(group.index).AtName("opening_paren"),
+			"Unbalanced parentheses",
+			fmt.Sprintf("Criteria %d opens a parenthesis that no criteria after it closes.", group.index),
+		)
+	}
+
+	return root, diags
+}
+
+// first returns the index of the first criterion of an expression.
+func (e *criteriaExpression) first() int {
+	if !e.group {
+		return e.index
+	}
+	return e.children[0].first()
+}
+
+// validateCriteria reports criteria that Jamf Pro would reject or evaluate
+// other than intended. Unknown values are not validated.
+func validateCriteria(criteria []criterion, criteriaPath path.Path) diag.Diagnostics {
+	expression, diags := parseCriteria(criteria, criteriaPath)
+
+	// The first criterion has nothing before it to be combined with.
+	if len(expression.children) > 0 {
+		first := expression.first()
+		if andOr := criteria[first].AndOr; andOr.ValueString() == "or" {
+			diags.AddAttributeError(
+				criteriaPath.AtListIndex(first).AtName("and_or"),
+				"Invalid and_or of the first criteria",
+				"The first criteria is not combined with a previous one, so its and_or must be \"and\" or unset.",
+			)
+		}
+	}
+
+	var lastPriority int64
+	hasLastPriority := false
+	priorities := make(map[int64]int)
+	for i, c := range criteria {
+		criterionPath := criteriaPath.AtListIndex(i)
+
+		if c.Name.IsNull() {
+			diags.AddAttributeError(
+				criterionPath.AtName("name"),
+				"Missing criteria name",
+				fmt.Sprintf("Criteria %d must have the name of the inventory criteria or extension attribute it checks.", i),
+			)
+		} else if !c.Name.IsUnknown() {
+			diags.Append(validateCriteriaName(c.Name.ValueString(), criterionPath.AtName("name"))...)
+		}
+
+		if c.Value.IsNull() {
+			diags.AddAttributeError(
+				criterionPath.AtName("value"),
+				"Missing criteria value",
+				fmt.Sprintf("Criteria %d must have a value to check %q against. Use an empty string to match an empty value.", i, c.Name.ValueString()),
+			)
+		}
+
+		if !c.SearchType.IsUnknown() && !c.Value.IsNull() && !c.Value.IsUnknown() {
+			diags.Append(validateCriteriaValue(c.SearchType.ValueString(), c.Value.ValueString(), criterionPath.AtName("value"))...)
+		}
+
+		// Criteria without a priority get their position in the list.
+		if c.Priority.IsUnknown() {
+			continue
+		}
+		priority := int64(i)
+		if !c.Priority.IsNull() {
+			priority = c.Priority.ValueInt64()
+		}
+		if other, ok := priorities[priority]; ok {
+			diags.AddAttributeError(
+				criterionPath.AtName("priority"),
+				"Duplicate criteria priority",
+				fmt.Sprintf("Criteria %d and %d both have priority %d.", other, i, priority),
+			)
+			continue
+		}
+		priorities[priority] = i
+		if hasLastPriority && priority < lastPriority {
+			diags.AddAttributeError(
+				criterionPath.AtName("priority"),
+				"Criteria out of order",
+				fmt.Sprintf("Criteria %d has priority %d, lower than the criteria before it. Jamf Pro evaluates criteria "+
+					"by priority, so list them in that order.", i, priority),
+			)
+		}
+		lastPriority, hasLastPriority = priority, true
+	}
+
+	return diags
+}
+
+// validateCriteriaName reports names of criteria that only differ from a
+// built-in one in case, as Jamf Pro does not match them. Other names may be
+// those of extension attributes, so names close to a built-in one are only
+// warnings.
+func validateCriteriaName(name string, namePath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, known := range computerCriteriaNames {
+		if name == known {
+			return diags
+		}
+	}
+	if strings.TrimSpace(name) == "" {
+		diags.AddAttributeError(namePath, "Missing criteria name", "The name of criteria must not be empty.")
+	} else if match, ok := nearestMatch(name, computerCriteriaNames, 0); ok {
+		diags.AddAttributeError(
+			namePath,
+			"Unknown criteria name",
+			fmt.Sprintf("%q is not a Jamf Pro inventory criteria. Did you mean %q?", name, match),
+		)
+	} else if match, ok := nearestMatch(name, computerCriteriaNames, 2); ok {
+		diags.AddAttributeWarning(
+			namePath,
+			"Unknown criteria name",
+			fmt.Sprintf("%q is not a Jamf Pro inventory criteria. Did you mean %q? Ignore this warning if it is the "+
+				"name of an extension attribute.", name, match),
+		)
+	}
+	return diags
+}
+
+// validateCriteriaValue reports values that the search type of their criteria
+// cannot compare with. Unsupported search types are reported by the schema.
+func validateCriteriaValue(searchType string, value string, valuePath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, t := range searchTypes {
+		if t.name != searchType || t.validateValue == nil {
+			continue
+		}
+		if err := t.validateValue(value); err != nil {
+			diags.AddAttributeError(
+				valuePath,
+				"Invalid criteria value",
+				fmt.Sprintf("The value of criteria with search_type %q is invalid: %s.", searchType, err),
+			)
+		}
+	}
+	return diags
+}
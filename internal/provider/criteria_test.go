@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testCriterion(name string, andOr string, value string) criterion {
+	return criterion{
+		Name:         types.StringValue(name),
+		Priority:     types.Int64Null(),
+		AndOr:        types.StringValue(andOr),
+		SearchType:   types.StringValue("is"),
+		Value:        types.StringValue(value),
+		OpeningParen: types.BoolNull(),
+		ClosingParen: types.BoolNull(),
+	}
+}
+
+func TestValidateCriteria(t *testing.T) {
+	criteriaPath := path.Root("criteria")
+
+	for name, tc := range map[string]struct {
+		criteria func() []criterion
+		// wantPaths are the paths of the expected errors, in order.
+		wantPaths []path.Path
+	}{
+		"valid": {
+			criteria: func() []criterion {
+				c := []criterion{
+					testCriterion("Application Title", "and", "Safari.app"),
+					testCriterion("Operating System Version", "and", "14.0"),
+					testCriterion("Computer Group", "or", "All Managed Clients"),
+				}
+				c[1].OpeningParen = types.BoolValue(true)
+				c[2].ClosingParen = types.BoolValue(true)
+				return c
+			},
+		},
+		"extension attribute": {
+			criteria: func() []criterion {
+				return []criterion{testCriterion("Last Backup Date", "", "")}
+			},
+		},
+		"unclosed parenthesis": {
+			criteria: func() []criterion {
+				c := []criterion{
+					testCriterion("Application Title", "and", "Safari.app"),
+					testCriterion("Application Title", "or", "Firefox.app"),
+				}
+				c[1].OpeningParen = types.BoolValue(true)
+				return c
+			},
+			wantPaths: []path.Path{criteriaPath.AtListIndex(1).AtName("opening_paren")},
+		},
+		"unopened parenthesis": {
+			criteria: func() []criterion {
+				c := []criterion{testCriterion("Application Title", "and", "Safari.app")}
+				c[0].ClosingParen = types.BoolValue(true)
+				return c
+			},
+			wantPaths: []path.Path{criteriaPath.AtListIndex(0).AtName("closing_paren")},
+		},
+		"or on the first criteria": {
+			criteria: func() []criterion {
+				c := []criterion{
+					testCriterion("Application Title", "or", "Safari.app"),
+					testCriterion("Application Title", "or", "Firefox.app"),
+				}
+				c[0].OpeningParen = types.BoolValue(true)
+				c[1].ClosingParen = types.BoolValue(true)
+				return c
+			},
+			wantPaths: []path.Path{criteriaPath.AtListIndex(0).AtName("and_or")},
+		},
+		"duplicate priorities": {
+			criteria: func() []criterion {
+				c := []criterion{
+					testCriterion("Application Title", "and", "Safari.app"),
+					testCriterion("Application Title", "or", "Firefox.app"),
+				}
+				c[1].Priority = types.Int64Value(0)
+				return c
+			},
+			wantPaths: []path.Path{criteriaPath.AtListIndex(1).AtName("priority")},
+		},
+		"priorities out of order": {
+			criteria: func() []criterion {
+				c := []criterion{
+					testCriterion("Application Title", "and", "Safari.app"),
+					testCriterion("Application Title", "or", "Firefox.app"),
+				}
+				c[0].Priority = types.Int64Value(5)
+				c[1].Priority = types.Int64Value(3)
+				return c
+			},
+			wantPaths: []path.Path{criteriaPath.AtListIndex(1).AtName("priority")},
+		},
+		"missing name and value": {
+			criteria: func() []criterion {
+				c := []criterion{testCriterion("", "and", "")}
+				c[0].Name = types.StringNull()
+				c[0].Value = types.StringNull()
+				return c
+			},
+			wantPaths: []path.Path{
+				criteriaPath.AtListIndex(0).AtName("name"),
+				criteriaPath.AtListIndex(0).AtName("value"),
+			},
+		},
+		"typo in name": {
+			criteria: func() []criterion {
+				return []criterion{testCriterion("Aplication Title", "and", "Safari.app")}
+			},
+		},
+		"name in other case": {
+			criteria: func() []criterion {
+				return []criterion{testCriterion("application title", "and", "Safari.app")}
+			},
+			wantPaths: []path.Path{criteriaPath.AtListIndex(0).AtName("name")},
+		},
+		"unknown values": {
+			criteria: func() []criterion {
+				c := []criterion{testCriterion("Application Title", "and", "Safari.app")}
+				c[0].Name = types.StringUnknown()
+				c[0].Value = types.StringUnknown()
+				c[0].Priority = types.Int64Unknown()
+				return c
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			diags := validateCriteria(tc.criteria(), criteriaPath)
+			errors := diags.Errors()
+			if len(errors) != len(tc.wantPaths) {
+				t.Fatalf("got %d errors, want %d: %v", len(errors), len(tc.wantPaths), errors)
+			}
+			for i, d := range errors {
+				withPath, ok := d.(interface{ Path() path.Path })
+				if !ok || !withPath.Path().Equal(tc.wantPaths[i]) {
+					t.Errorf("error %d %q is not at %s", i, d.Summary(), tc.wantPaths[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCriteriaNameSuggestion(t *testing.T) {
+	diags := validateCriteriaName("Aplication Title", path.Root("name"))
+	if diags.HasError() || len(diags.Warnings()) != 1 {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+	if detail := diags.Warnings()[0].Detail(); detail != `"Aplication Title" is not a Jamf Pro inventory criteria. Did you mean "Application Title"? Ignore this warning if it is the name of an extension attribute.` {
+		t.Errorf("unexpected detail %q", detail)
+	}
+
+	diags = validateCriteriaName("operating system", path.Root("name"))
+	if !diags.HasError() {
+		t.Fatal("expected an error")
+	}
+	if detail := diags.Errors()[0].Detail(); detail != `"operating system" is not a Jamf Pro inventory criteria. Did you mean "Operating System"?` {
+		t.Errorf("unexpected detail %q", detail)
+	}
+}
+
+func TestValidateCriteriaNameCloseToBuiltIn(t *testing.T) {
+	for name, wantError := range map[string]bool{
+		"application title": true,
+		"SERIAL NUMBER":     true,
+		"Aplication Title":  false,
+		"Operating Sytem":   false,
+		"Applicaton Titel":  false,
+		"Departments":       false,
+		"Serial Numbers":    false,
+		"department name":   false,
+		"Role":              false,
+		"Sites":             false,
+		"Computer Name":     false,
+		"Lab Room Location": false,
+	} {
+		diags := validateCriteriaName(name, path.Root("name"))
+		if diags.HasError() != wantError {
+			t.Errorf("validateCriteriaName(%q) = %v, want error %t", name, diags, wantError)
+		}
+	}
+
+	diags := validateCriteriaName("Sites", path.Root("name"))
+	if len(diags.Warnings()) != 1 || diags.Warnings()[0].Detail() != `"Sites" is not a Jamf Pro inventory criteria. Did you mean "Site"? Ignore this warning if it is the name of an extension attribute.` {
+		t.Errorf("validateCriteriaName(%q) = %v, want a warning suggesting \"Site\"", "Sites", diags)
+	}
+}
+
+func TestValidateCriteriaValue(t *testing.T) {
+	for _, tc := range []struct {
+		searchType string
+		value      string
+		valid      bool
+	}{
+		{"is", "", true},
+		{"like", "Safari", true},
+		{"greater than", "13", true},
+		{"greater than or equal", "13.4.1", true},
+		{"less than", "thirteen", false},
+		{"more than x days ago", "30", true},
+		{"less than x days ago", "-1", false},
+		{"before (yyyy-mm-dd)", "2024-02-29", true},
+		{"after (yyyy-mm-dd)", "2023-02-29", false},
+		{"after (yyyy-mm-dd)", "02/01/2023", false},
+		{"matches regex", `^Safari [0-9]+\.app$`, true},
+		{"does not match regex", "Safari (", false},
+		{"member of", "All Managed Clients", true},
+		{"not member of", " ", false},
+	} {
+		diags := validateCriteriaValue(tc.searchType, tc.value, path.Root("value"))
+		if diags.HasError() == tc.valid {
+			t.Errorf("validateCriteriaValue(%q, %q) = %v, want valid %t", tc.searchType, tc.value, diags, tc.valid)
+		}
+	}
+}
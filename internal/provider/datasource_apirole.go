@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jc0b/go-jamfpro-api/jamfpro"
+)
+
+var _ datasource.DataSource = &ApiRoleDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &ApiRoleDataSource{}
+
+func NewApiRoleDataSource() datasource.DataSource {
+	return &ApiRoleDataSource{}
+}
+
+type ApiRoleDataSource struct {
+	client *jamfpro.Client
+	api    *apiClient
+}
+
+func (d *ApiRoleDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_api_role"
+}
+
+func (d *ApiRoleDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+func (d *ApiRoleDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description:         "Allows details of an API role to be retrieved by its ID or name.",
+		MarkdownDescription: "The data source `jamfpro_api_role` allows details of an API role to be retrieved by its `ID` or name.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description:         "ID of the API role.",
+				MarkdownDescription: "`ID` of the API role.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the API role.",
+				MarkdownDescription: "`name` of the API role.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"privileges": schema.SetAttribute{
+				Description: "The privileges granted to the API role.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *ApiRoleDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data apirole
+
+	// Read Terraform configuration data into the model
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	id := int(data.Id.ValueInt64())
+	if data.Id.IsNull() {
+		var diags diag.Diagnostics
+		id, diags = lookUpIDByName(ctx, d.api, "/api/v1/api-roles", "displayName", "API role", data.Name.ValueString())
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	jamfApiRole, resp, err := d.client.ApiRoles.GetByID(ctx, id)
+	if isNotFound(resp) {
+		response.Diagnostics.AddError(
+			"No matching API role",
+			fmt.Sprintf("No API role with ID '%d' exists in Jamf Pro.", id),
+		)
+		return
+	}
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get API role with ID '%d', got error: %s", id, err),
+		)
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, apiRoleForState(jamfApiRole))...)
+}
+
+func (d *ApiRoleDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	data, ok := request.ProviderData.(*providerData)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+	d.api = data.api
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ApiRolePrivilegesDataSource{}
+
+func NewApiRolePrivilegesDataSource() datasource.DataSource {
+	return &ApiRolePrivilegesDataSource{}
+}
+
+type ApiRolePrivilegesDataSource struct {
+	api *apiClient
+}
+
+func (d *ApiRolePrivilegesDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_api_role_privileges"
+}
+
+func (d *ApiRolePrivilegesDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Lists the privileges API roles can grant.",
+		MarkdownDescription: "The data source `jamfpro_api_role_privileges` lists the privileges API roles can grant " +
+			"on the Jamf Pro instance, which depend on its version.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the list.",
+				Computed:    true,
+			},
+			"privileges": schema.ListAttribute{
+				Description:         "The privileges API roles can grant, sorted by name.",
+				MarkdownDescription: "The privileges API roles can grant, sorted by name, as set in `privileges` of `jamfpro_api_role`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ApiRolePrivilegesDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	privileges, err := d.api.apiRolePrivileges(ctx)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to list API role privileges, got error: %s", err),
+		)
+		return
+	}
+
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), apiRolePrivilegesPath)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("privileges"), privileges)...)
+}
+
+func (d *ApiRolePrivilegesDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	data, ok := request.ProviderData.(*providerData)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	d.api = data.api
+}
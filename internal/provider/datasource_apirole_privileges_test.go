@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccApiRolePrivilegesDataSource(t *testing.T) {
+	dataSourceName := "data.jamfpro_api_role_privileges.test"
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "jamfpro_api_role_privileges" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", "/api/v1/api-role-privileges"),
+					resource.TestCheckTypeSetElemAttr(dataSourceName, "privileges.*", "Read Computers"),
+					resource.TestCheckTypeSetElemAttr(dataSourceName, "privileges.*", "Update API Roles"),
+				),
+			},
+		},
+	})
+}
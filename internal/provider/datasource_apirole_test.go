@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccApiRoleDataSource(t *testing.T) {
+	Name := acctest.RandString(12)
+	dataSourceName := "data.jamfpro_api_role.test"
+	resourceName := "jamfpro_api_role.test_role"
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApiRoleResourceConfig(Name, []string{"Read Buildings", "Read Departments"}) + fmt.Sprintf(`
+data "jamfpro_api_role" "test" {
+  name = %q
+  depends_on = [jamfpro_api_role.test_role]
+}
+`, Name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						dataSourceName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttr(
+						dataSourceName, "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr(
+						dataSourceName, "privileges.*", "Read Departments"),
+				),
+			},
+		},
+	})
+}
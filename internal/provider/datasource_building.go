@@ -1 +1,143 @@
 package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/jc0b/go-jamfpro-api/jamfpro"
+)
+
+var _ datasource.DataSource = &BuildingDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &BuildingDataSource{}
+
+func NewBuildingDataSource() datasource.DataSource {
+	return &BuildingDataSource{}
+}
+
+type BuildingDataSource struct {
+	client *jamfpro.Client
+	api    *apiClient
+}
+
+func (b *BuildingDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_building"
+}
+
+func (b *BuildingDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description:         "Allows details of a building to be retrieved by its ID or name.",
+		MarkdownDescription: "The data source `jamfpro_building` allows details of a building to be retrieved by its `ID` or name.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description:         "ID of the building.",
+				MarkdownDescription: "`ID` of the building.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the building.",
+				MarkdownDescription: "`name` of the building.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"street_address1": schema.StringAttribute{
+				Description: "A street address for the building.",
+				Computed:    true,
+			},
+			"street_address2": schema.StringAttribute{
+				Description: "A second street address for the building.",
+				Computed:    true,
+			},
+			"city": schema.StringAttribute{
+				Description: "City of the building.",
+				Computed:    true,
+			},
+			"state_province": schema.StringAttribute{
+				Description: "State/province of the building.",
+				Computed:    true,
+			},
+			"zip_postal_code": schema.StringAttribute{
+				Description: "ZIP/Postal code of the building.",
+				Computed:    true,
+			},
+			"country": schema.StringAttribute{
+				Description: "Country of the building.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (b *BuildingDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+func (b *BuildingDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data building
+
+	// Read Terraform configuration data into the model
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	id := int(data.Id.ValueInt64())
+	if data.Id.IsNull() {
+		var diags diag.Diagnostics
+		id, diags = lookUpIDByName(ctx, b.api, "/api/v1/buildings", "name", "building", data.Name.ValueString())
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	jamfBuilding, resp, err := b.client.Buildings.GetByID(ctx, id)
+	if isNotFound(resp) {
+		response.Diagnostics.AddError(
+			"No matching building",
+			fmt.Sprintf("No building with ID '%d' exists in Jamf Pro.", id),
+		)
+		return
+	}
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get building with ID '%d', got error: %s", id, err),
+		)
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, buildingForState(jamfBuilding))...)
+}
+
+func (b *BuildingDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	data, ok := request.ProviderData.(*providerData)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	b.client = data.client
+	b.api = data.api
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBuildingDataSource(t *testing.T) {
+	Name := acctest.RandString(12)
+	City := acctest.RandString(12)
+	b1DataSourceName := "data.jamfpro_building.by_name"
+	b2DataSourceName := "data.jamfpro_building.by_id"
+	bResourceName := "jamfpro_building.test"
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBuildingDataSourceConfig(Name, City),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						b1DataSourceName, "id", bResourceName, "id"),
+					resource.TestCheckResourceAttr(
+						b1DataSourceName, "city", City),
+					resource.TestCheckResourceAttr(
+						b1DataSourceName, "country", "Netherlands"),
+					resource.TestCheckResourceAttr(
+						b2DataSourceName, "name", Name),
+					resource.TestCheckResourceAttr(
+						b2DataSourceName, "street_address1", "Dam 1"),
+				),
+			},
+			{
+				Config: `
+data "jamfpro_building" "missing" {
+  name = "No Such Building"
+}`,
+				ExpectError: regexp.MustCompile(`No building named 'No Such Building' exists`),
+			},
+		},
+	})
+}
+
+func testAccBuildingDataSourceConfig(name string, city string) string {
+	return fmt.Sprintf(`
+resource "jamfpro_building" "test" {
+  name            = %q
+  street_address1 = "Dam 1"
+  street_address2 = ""
+  city            = %q
+  state_province  = "Noord-Holland"
+  zip_postal_code = "1012 JS"
+  country         = "Netherlands"
+}
+
+data "jamfpro_building" "by_name" {
+  name = jamfpro_building.test.name
+}
+
+data "jamfpro_building" "by_id" {
+  id = jamfpro_building.test.id
+}
+`, name, city)
+}
@@ -93,16 +93,16 @@ func (c *CategoryDataSource) Configure(ctx context.Context, request datasource.C
 		return
 	}
 
-	client, ok := request.ProviderData.(*jamfpro.Client)
+	data, ok := request.ProviderData.(*providerData)
 
 	if !ok {
 		response.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *jamfpro.Client, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
 		)
 
 		return
 	}
 
-	c.client = client
+	c.client = data.client
 }
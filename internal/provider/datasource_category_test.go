@@ -19,7 +19,7 @@ func TestAccCategoryDataSource(t *testing.T) {
 	c1DataSourceName := "data.jamfpro_category.test1_by_name"
 	c2DataSourceName := "data.jamfpro_category.test2_by_id"
 
-	resource.Test(t, resource.TestCase{
+	testAccRun(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
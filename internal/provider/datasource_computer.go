@@ -108,18 +108,18 @@ func (c *ComputerDataSource) Configure(ctx context.Context, request datasource.C
 		return
 	}
 
-	client, ok := request.ProviderData.(*jamfpro.Client)
+	data, ok := request.ProviderData.(*providerData)
 
 	if !ok {
 		response.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *jamfpro.Client, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
 		)
 
 		return
 	}
 
-	c.client = client
+	c.client = data.client
 }
 
 func (c *ComputerDataSource) ValidateConfig(ctx context.Context, request datasource.ValidateConfigRequest, response *datasource.ValidateConfigResponse) {
@@ -13,7 +13,7 @@ func TestAccComputerDataSource(t *testing.T) {
 	c1DataSourceName := "data.jamfpro_computer.test1_by_name"
 	c2DataSourceName := "data.jamfpro_computer.test2_by_serial"
 
-	resource.Test(t, resource.TestCase{
+	testAccRun(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
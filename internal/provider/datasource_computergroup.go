@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jc0b/go-jamfpro-api/jamfpro"
+)
+
+var _ datasource.DataSource = &ComputerGroupDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &ComputerGroupDataSource{}
+
+func NewComputerGroupDataSource() datasource.DataSource {
+	return &ComputerGroupDataSource{}
+}
+
+type ComputerGroupDataSource struct {
+	client *jamfpro.Client
+}
+
+func (d *ComputerGroupDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_computergroup"
+}
+
+func (d *ComputerGroupDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+func (d *ComputerGroupDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Allows details of a static computer group to be retrieved by its ID or name.",
+		MarkdownDescription: "The data source `jamfpro_computergroup` allows details of a static computer group to be retrieved by its `ID` or name. " +
+			"Smart groups are retrieved with `jamfpro_smartcomputergroup`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description:         "ID of the static computer group.",
+				MarkdownDescription: "`ID` of the static computer group.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the static computer group.",
+				MarkdownDescription: "`name` of the static computer group.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"computers": schema.SetNestedAttribute{
+				Description: "Represents computers that are members of a static group.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description:         "ID of the computer.",
+							MarkdownDescription: "`ID` of the computer.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							Description:         "Name of the computer.",
+							MarkdownDescription: "`name` of the computer.",
+							Computed:            true,
+						},
+						"serial_number": schema.StringAttribute{
+							Description:         "Serial number of the computer.",
+							MarkdownDescription: "`serial_number` of the computer.",
+							Computed:            true,
+						},
+						"udid": schema.StringAttribute{
+							Description:         "Hardware UDID of the computer.",
+							MarkdownDescription: "`udid` of the computer.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// computerGroupDataSourceModel is the computer group model without the resource's timeouts.
+type computerGroupDataSourceModel struct {
+	Id        types.Int64  `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Computers types.Set    `tfsdk:"computers"`
+}
+
+func (d *ComputerGroupDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data computerGroupDataSourceModel
+
+	// Read Terraform configuration data into the model
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var group *jamfpro.ComputerGroup
+	var resp *jamfpro.Response
+	var err error
+	description := fmt.Sprintf("ID '%d'", data.Id.ValueInt64())
+	if !data.Id.IsNull() {
+		group, resp, err = d.client.ComputerGroups.GetByID(ctx, int(data.Id.ValueInt64()))
+	} else {
+		description = fmt.Sprintf("name '%s'", data.Name.ValueString())
+		group, resp, err = d.client.ComputerGroups.GetByName(ctx, data.Name.ValueString())
+	}
+	if isNotFound(resp) {
+		response.Diagnostics.AddError(
+			"No matching computer group",
+			fmt.Sprintf("No computer group with %s exists in Jamf Pro.", description),
+		)
+		return
+	}
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get computer group with %s, got error: %s", description, err),
+		)
+		return
+	}
+
+	if group.IsSmart {
+		response.Diagnostics.AddError(
+			"Not a static computer group",
+			fmt.Sprintf("Computer group %q with ID %d is a smart group. Read it with the jamfpro_smartcomputergroup "+
+				"data source instead.", group.Name, group.Id),
+		)
+		return
+	}
+
+	state := computerGroupForState(group)
+	response.Diagnostics.Append(response.State.Set(ctx, computerGroupDataSourceModel{
+		Id:        state.Id,
+		Name:      state.Name,
+		Computers: state.Computers,
+	})...)
+}
+
+func (d *ComputerGroupDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	data, ok := request.ProviderData.(*providerData)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
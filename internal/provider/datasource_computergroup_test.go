@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccComputerGroupDataSource(t *testing.T) {
+	Name := acctest.RandString(12)
+	serialNumber := randomSerialNumber()
+	byNameDataSourceName := "data.jamfpro_computergroup.by_name"
+	byIdDataSourceName := "data.jamfpro_computergroup.by_id"
+	resourceName := "jamfpro_computergroup.test"
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputerGroupResourceConfig(serialNumber, Name) + `
+data "jamfpro_computergroup" "by_name" {
+  name = jamfpro_computergroup.test.name
+}
+
+data "jamfpro_computergroup" "by_id" {
+  id = jamfpro_computergroup.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						byNameDataSourceName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttr(
+						byIdDataSourceName, "name", Name),
+					resource.TestCheckResourceAttr(
+						byIdDataSourceName, "computers.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(
+						byNameDataSourceName, "computers.*", map[string]string{"serial_number": serialNumber}),
+				),
+			},
+			{
+				Config: `
+data "jamfpro_computergroup" "missing" {
+  name = "No Such Group"
+}`,
+				ExpectError: regexp.MustCompile(`No matching computer group`),
+			},
+			// A static group is not read as a smart group
+			{
+				Config: testAccComputerGroupResourceConfig(serialNumber, Name) + `
+data "jamfpro_smartcomputergroup" "test" {
+  name = jamfpro_computergroup.test.name
+}
+`,
+				ExpectError: regexp.MustCompile(`Not a smart computer group`),
+			},
+		},
+	})
+}
+
+func TestAccSmartComputerGroupDataSource(t *testing.T) {
+	Name := acctest.RandString(12)
+	dataSourceName := "data.jamfpro_smartcomputergroup.test"
+
+	smartGroup := fmt.Sprintf(`
+resource "jamfpro_smartcomputergroup" "test" {
+  name     = %q
+  criteria = [
+	{
+		and_or = "and"
+		closing_paren = false
+		name = "Application Title"
+		opening_paren = false
+		priority = 0
+		search_type = "is"
+		value = "Safari.app"
+	},
+  ]
+}
+`, Name)
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: smartGroup + `
+data "jamfpro_smartcomputergroup" "test" {
+  id = jamfpro_smartcomputergroup.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						dataSourceName, "name", Name),
+					resource.TestCheckResourceAttr(
+						dataSourceName, "criteria.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(
+						dataSourceName, "criteria.*", map[string]string{"value": "Safari.app"}),
+				),
+			},
+			// A smart group is not read as a static group
+			{
+				Config: smartGroup + `
+data "jamfpro_computergroup" "test" {
+  id = jamfpro_smartcomputergroup.test.id
+}
+`,
+				ExpectError: regexp.MustCompile(`Not a static computer group`),
+			},
+		},
+	})
+}
@@ -0,0 +1,392 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var _ datasource.DataSource = &ComputerInventoryDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &ComputerInventoryDataSource{}
+
+func NewComputerInventoryDataSource() datasource.DataSource {
+	return &ComputerInventoryDataSource{}
+}
+
+type ComputerInventoryDataSource struct {
+	api *apiClient
+}
+
+func (c *ComputerInventoryDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_computer_inventory"
+}
+
+func (c *ComputerInventoryDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Allows the inventory of a computer to be retrieved by its ID, name, or serial number.",
+		MarkdownDescription: "The data source `jamfpro_computer_inventory` allows the inventory of a computer to be retrieved " +
+			"by its `ID`, name, or serial number. Only the sections of the inventory listed in `sections` are fetched.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description:         "ID of the computer.",
+				MarkdownDescription: "`ID` of the computer.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the computer. Null when looked up otherwise and the GENERAL section is not fetched.",
+				MarkdownDescription: "`name` of the computer. Null when looked up otherwise and the `GENERAL` section is not fetched.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"serial_number": schema.StringAttribute{
+				Description:         "Serial number of the computer. Null when looked up otherwise and the HARDWARE section is not fetched.",
+				MarkdownDescription: "`serial_number` of the computer. Null when looked up otherwise and the `HARDWARE` section is not fetched.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"udid": schema.StringAttribute{
+				Description:         "Hardware UDID of the computer.",
+				MarkdownDescription: "`udid` of the computer.",
+				Computed:            true,
+			},
+			"sections": schema.SetAttribute{
+				Description: fmt.Sprintf("The sections of the inventory to fetch, among %s. All sections are fetched "+
+					"if unset.", strings.Join(inventorySections, ", ")),
+				MarkdownDescription: fmt.Sprintf("The sections of the inventory to fetch, among `%s`. All sections are "+
+					"fetched if unset.", strings.Join(inventorySections, "`, `")),
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(inventorySections...)),
+				},
+			},
+			"general": schema.SingleNestedAttribute{
+				Description: "The GENERAL section of the inventory.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description: "Name of the computer.",
+						Computed:    true,
+					},
+					"last_ip_address": schema.StringAttribute{
+						Description: "IP address the computer last contacted Jamf Pro from.",
+						Computed:    true,
+					},
+					"last_reported_ip": schema.StringAttribute{
+						Description: "IP address last reported by the computer.",
+						Computed:    true,
+					},
+					"last_contact_time": schema.StringAttribute{
+						Description: "Time the computer last contacted Jamf Pro.",
+						Computed:    true,
+					},
+					"report_date": schema.StringAttribute{
+						Description: "Time of the last inventory report of the computer.",
+						Computed:    true,
+					},
+					"asset_tag": schema.StringAttribute{
+						Description: "Asset tag of the computer.",
+						Computed:    true,
+					},
+					"platform": schema.StringAttribute{
+						Description: "Platform of the computer.",
+						Computed:    true,
+					},
+					"supervised": schema.BoolAttribute{
+						Description: "Whether the computer is supervised.",
+						Computed:    true,
+					},
+					"mdm_capable": schema.BoolAttribute{
+						Description: "Whether the computer is MDM capable.",
+						Computed:    true,
+					},
+					"managed": schema.BoolAttribute{
+						Description: "Whether the computer is managed.",
+						Computed:    true,
+					},
+				},
+			},
+			"hardware": schema.SingleNestedAttribute{
+				Description: "The HARDWARE section of the inventory.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"make": schema.StringAttribute{
+						Description: "Make of the computer.",
+						Computed:    true,
+					},
+					"model": schema.StringAttribute{
+						Description: "Model of the computer.",
+						Computed:    true,
+					},
+					"model_identifier": schema.StringAttribute{
+						Description: "Model identifier of the computer, e.g. Mac14,9.",
+						Computed:    true,
+					},
+					"serial_number": schema.StringAttribute{
+						Description: "Serial number of the computer.",
+						Computed:    true,
+					},
+					"processor_type": schema.StringAttribute{
+						Description: "Processor type of the computer.",
+						Computed:    true,
+					},
+					"processor_architecture": schema.StringAttribute{
+						Description: "Processor architecture of the computer, e.g. arm64.",
+						Computed:    true,
+					},
+					"total_ram_megabytes": schema.Int64Attribute{
+						Description: "Total RAM of the computer, in megabytes.",
+						Computed:    true,
+					},
+					"mac_address": schema.StringAttribute{
+						Description: "MAC address of the computer.",
+						Computed:    true,
+					},
+					"apple_silicon": schema.BoolAttribute{
+						Description: "Whether the computer has Apple silicon.",
+						Computed:    true,
+					},
+				},
+			},
+			"operating_system": schema.SingleNestedAttribute{
+				Description: "The OPERATING_SYSTEM section of the inventory.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description: "Name of the operating system.",
+						Computed:    true,
+					},
+					"version": schema.StringAttribute{
+						Description: "Version of the operating system.",
+						Computed:    true,
+					},
+					"build": schema.StringAttribute{
+						Description: "Build of the operating system.",
+						Computed:    true,
+					},
+					"supplemental_build_version": schema.StringAttribute{
+						Description: "Build of the Rapid Security Response applied to the operating system.",
+						Computed:    true,
+					},
+					"file_vault2_status": schema.StringAttribute{
+						Description: "FileVault 2 status of the computer, e.g. ALL_ENCRYPTED.",
+						Computed:    true,
+					},
+				},
+			},
+			"user_and_location": schema.SingleNestedAttribute{
+				Description: "The USER_AND_LOCATION section of the inventory.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"username": schema.StringAttribute{
+						Description: "Username of the user of the computer.",
+						Computed:    true,
+					},
+					"realname": schema.StringAttribute{
+						Description: "Full name of the user of the computer.",
+						Computed:    true,
+					},
+					"email": schema.StringAttribute{
+						Description: "Email address of the user of the computer.",
+						Computed:    true,
+					},
+					"position": schema.StringAttribute{
+						Description: "Position of the user of the computer.",
+						Computed:    true,
+					},
+					"phone": schema.StringAttribute{
+						Description: "Phone number of the user of the computer.",
+						Computed:    true,
+					},
+					"department_id": schema.Int64Attribute{
+						Description: "ID of the department of the computer.",
+						Computed:    true,
+					},
+					"building_id": schema.Int64Attribute{
+						Description: "ID of the building of the computer.",
+						Computed:    true,
+					},
+					"room": schema.StringAttribute{
+						Description: "Room of the computer.",
+						Computed:    true,
+					},
+				},
+			},
+			"extension_attributes": schema.ListNestedAttribute{
+				Description: "The EXTENSION_ATTRIBUTES section of the inventory, ordered by definition ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"definition_id": schema.Int64Attribute{
+							Description: "ID of the definition of the extension attribute.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the extension attribute.",
+							Computed:    true,
+						},
+						"values": schema.ListAttribute{
+							Description: "Values of the extension attribute for the computer.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"group_memberships": schema.ListNestedAttribute{
+				Description: "The GROUP_MEMBERSHIPS section of the inventory, ordered by group ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"group_id": schema.Int64Attribute{
+							Description: "ID of the computer group.",
+							Computed:    true,
+						},
+						"group_name": schema.StringAttribute{
+							Description: "Name of the computer group.",
+							Computed:    true,
+						},
+						"smart_group": schema.BoolAttribute{
+							Description: "Whether the computer group is a smart group.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *ComputerInventoryDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+			path.MatchRoot("serial_number"),
+		),
+	}
+}
+
+func (c *ComputerInventoryDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data computerInventory
+
+	// Read Terraform configuration data into the model
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	sections := inventorySections
+	if !data.Sections.IsNull() {
+		sections = nil
+		response.Diagnostics.Append(data.Sections.ElementsAs(ctx, &sections, false)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+	query := url.Values{"section": sections}
+
+	var jamfComputer inventoryComputer
+	if !data.Id.IsNull() {
+		path := "/api/v1/computers-inventory/" + strconv.FormatInt(data.Id.ValueInt64(), 10)
+		err := c.api.getJSON(ctx, path, query, &jamfComputer)
+		if isNotFoundError(err) {
+			response.Diagnostics.AddError(
+				"No matching computer",
+				fmt.Sprintf("No computer with ID '%d' exists in Jamf Pro.", data.Id.ValueInt64()),
+			)
+			return
+		}
+		if err != nil {
+			response.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to get computer with ID '%d', got error: %s", data.Id.ValueInt64(), err),
+			)
+			return
+		}
+	} else {
+		description := fmt.Sprintf("named '%s'", data.Name.ValueString())
+		query.Set("filter", "general.name=="+rsqlQuote(data.Name.ValueString()))
+		if !data.SerialNumber.IsNull() {
+			description = fmt.Sprintf("with serial number '%s'", data.SerialNumber.ValueString())
+			query.Set("filter", "hardware.serialNumber=="+rsqlQuote(data.SerialNumber.ValueString()))
+		}
+		computers, err := listAll[inventoryComputer](ctx, c.api, "/api/v1/computers-inventory", query)
+		if err != nil {
+			response.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to look up computer %s, got error: %s", description, err),
+			)
+			return
+		}
+		switch len(computers) {
+		case 0:
+			response.Diagnostics.AddError(
+				"No matching computer",
+				fmt.Sprintf("No computer %s exists in Jamf Pro.", description),
+			)
+			return
+		case 1:
+			jamfComputer = computers[0]
+		default:
+			ids := make([]string, 0, len(computers))
+			for _, computer := range computers {
+				ids = append(ids, computer.Id)
+			}
+			response.Diagnostics.AddError(
+				"Several matching computers",
+				fmt.Sprintf("%d computers are %s (IDs %s). Look it up by its id instead.", len(computers), description, strings.Join(ids, ", ")),
+			)
+			return
+		}
+	}
+
+	state, diags := computerInventoryForState(&jamfComputer, data.Sections)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	// Keep the name or serial number the computer was looked up by when its section was not fetched.
+	if state.Name.IsNull() {
+		state.Name = data.Name
+	}
+	if state.SerialNumber.IsNull() {
+		state.SerialNumber = data.SerialNumber
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, state)...)
+}
+
+func (c *ComputerInventoryDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	data, ok := request.ProviderData.(*providerData)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	c.api = data.api
+}
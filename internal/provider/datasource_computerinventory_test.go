@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestComputerInventoryForState(t *testing.T) {
+	var c inventoryComputer
+	err := json.Unmarshal([]byte(`{
+  "id": "12",
+  "udid": "UDID",
+  "general": null,
+  "hardware": {"serialNumber": "C02ABC", "totalRamMegabytes": 8192},
+  "userAndLocation": {"departmentId": "3", "buildingId": null},
+  "groupMemberships": [
+    {"groupId": "10", "groupName": "Later", "smartGroup": true},
+    {"groupId": "9", "groupName": "Earlier", "smartGroup": false}
+  ]
+}`), &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, diags := computerInventoryForState(&c, types.SetNull(types.StringType))
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if state.Id.ValueInt64() != 12 || state.SerialNumber.ValueString() != "C02ABC" {
+		t.Errorf("unexpected id %s or serial number %s", state.Id, state.SerialNumber)
+	}
+	if !state.Name.IsNull() || !state.General.IsNull() || !state.ExtensionAttributes.IsNull() {
+		t.Errorf("sections that were not fetched are not null: name %s, general %s, extension attributes %s",
+			state.Name, state.General, state.ExtensionAttributes)
+	}
+	location := state.UserAndLocation.Attributes()
+	if location["department_id"] != types.Int64Value(3) || !location["building_id"].IsNull() {
+		t.Errorf("unexpected user and location %s", state.UserAndLocation)
+	}
+	memberships := state.GroupMemberships.Elements()
+	if len(memberships) != 2 || memberships[0].(types.Object).Attributes()["group_name"] != types.StringValue("Earlier") {
+		t.Errorf("group memberships %s are not ordered by group ID", state.GroupMemberships)
+	}
+}
+
+func TestAccComputerInventoryDataSource(t *testing.T) {
+	Name := acctest.RandString(12)
+	serialNumber := randomSerialNumber()
+	byIdDataSourceName := "data.jamfpro_computer_inventory.by_id"
+	bySerialDataSourceName := "data.jamfpro_computer_inventory.by_serial"
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputerInventoryDataSourceConfig(serialNumber, Name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(byIdDataSourceName, "name", Name),
+					resource.TestCheckResourceAttr(byIdDataSourceName, "hardware.serial_number", serialNumber),
+					resource.TestCheckResourceAttrSet(byIdDataSourceName, "operating_system.version"),
+					resource.TestCheckResourceAttr(byIdDataSourceName, "group_memberships.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						byIdDataSourceName, "group_memberships.0.group_id", "jamfpro_computergroup.test", "id"),
+					resource.TestCheckResourceAttrPair(
+						bySerialDataSourceName, "id", "jamfpro_computer.test", "id"),
+					resource.TestCheckResourceAttr(bySerialDataSourceName, "general.name", Name),
+					resource.TestCheckNoResourceAttr(bySerialDataSourceName, "hardware.model"),
+					resource.TestCheckNoResourceAttr(bySerialDataSourceName, "group_memberships.#"),
+				),
+			},
+			{
+				Config: `
+data "jamfpro_computer_inventory" "bad_section" {
+  id       = 1
+  sections = ["SOFTWARE_UPDATES"]
+}`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+		},
+	})
+}
+
+func testAccComputerInventoryDataSourceConfig(serialNumber string, name string) string {
+	return fmt.Sprintf(`
+resource "jamfpro_computer" "test" {
+  name          = %[2]q
+  serial_number = %[1]q
+}
+
+resource "jamfpro_computergroup" "test" {
+  name      = "%[2]s group"
+  computers = [jamfpro_computer.test]
+}
+
+data "jamfpro_computer_inventory" "by_id" {
+  id = jamfpro_computer.test.id
+
+  depends_on = [jamfpro_computergroup.test]
+}
+
+data "jamfpro_computer_inventory" "by_serial" {
+  serial_number = jamfpro_computer.test.serial_number
+  sections      = ["GENERAL"]
+}
+`, serialNumber, name)
+}
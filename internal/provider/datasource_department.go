@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/jc0b/go-jamfpro-api/jamfpro"
+)
+
+var _ datasource.DataSource = &DepartmentDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &DepartmentDataSource{}
+
+func NewDepartmentDataSource() datasource.DataSource {
+	return &DepartmentDataSource{}
+}
+
+type DepartmentDataSource struct {
+	client *jamfpro.Client
+	api    *apiClient
+}
+
+func (d *DepartmentDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_department"
+}
+
+func (d *DepartmentDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+func (d *DepartmentDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description:         "Allows details of a department to be retrieved by its ID or name.",
+		MarkdownDescription: "The data source `jamfpro_department` allows details of a department to be retrieved by its `ID` or name.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description:         "ID of the department.",
+				MarkdownDescription: "`ID` of the department.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the department.",
+				MarkdownDescription: "`name` of the department.",
+				Optional:            true,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DepartmentDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data department
+
+	// Read Terraform configuration data into the model
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	id := int(data.Id.ValueInt64())
+	if data.Id.IsNull() {
+		var diags diag.Diagnostics
+		id, diags = lookUpIDByName(ctx, d.api, "/api/v1/departments", "name", "department", data.Name.ValueString())
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	jamfDepartment, resp, err := d.client.Departments.GetByID(ctx, id)
+	if isNotFound(resp) {
+		response.Diagnostics.AddError(
+			"No matching department",
+			fmt.Sprintf("No department with ID '%d' exists in Jamf Pro.", id),
+		)
+		return
+	}
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get department with ID '%d', got error: %s", id, err),
+		)
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, departmentForState(jamfDepartment))...)
+}
+
+func (d *DepartmentDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	data, ok := request.ProviderData.(*providerData)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+	d.api = data.api
+}
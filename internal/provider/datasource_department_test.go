@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDepartmentDataSource(t *testing.T) {
+	Name := acctest.RandString(12)
+	byNameDataSourceName := "data.jamfpro_department.by_name"
+	byIdDataSourceName := "data.jamfpro_department.by_id"
+	dResourceName := "jamfpro_department.test"
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDepartmentDataSourceConfig(Name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						byNameDataSourceName, "id", dResourceName, "id"),
+					resource.TestCheckResourceAttr(
+						byIdDataSourceName, "name", Name),
+				),
+			},
+			{
+				Config: `
+data "jamfpro_department" "missing" {
+  name = "No Such Department"
+}`,
+				ExpectError: regexp.MustCompile(`No department named 'No Such Department' exists`),
+			},
+		},
+	})
+}
+
+func testAccDepartmentDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "jamfpro_department" "test" {
+  name = %q
+}
+
+data "jamfpro_department" "by_name" {
+  name = jamfpro_department.test.name
+}
+
+data "jamfpro_department" "by_id" {
+  id = jamfpro_department.test.id
+}
+`, name)
+}
@@ -0,0 +1,349 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jc0b/go-jamfpro-api/jamfpro"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var _ datasource.DataSource = &listDataSource[jamfpro.Category, category]{}
+
+// listDataSource lists the objects of a Pro API collection selected by an RSQL
+// filter. T is the type of the listed objects in the API, M their model, which
+// is that of the data source of a single object.
+type listDataSource[T any, M any] struct {
+	// typeName is the data source type name without the provider prefix, e.g. categories.
+	typeName string
+	// noun is the plural name of the listed objects in descriptions, e.g. categories.
+	noun string
+	// collection is the path of the Pro API collection, e.g. /api/v1/categories.
+	collection string
+	// query holds the query parameters sent besides the filter, sort and paging.
+	query url.Values
+	// filterExample is an example filter, in the Pro API field names of the collection.
+	filterExample string
+	// object is the data source of a single object, whose schema the listed objects share.
+	object datasource.DataSource
+	// forState converts a listed object to its model. It returns false when
+	// the object no longer exists.
+	forState func(ctx context.Context, client *jamfpro.Client, object T) (M, bool, error)
+
+	client *jamfpro.Client
+	api    *apiClient
+}
+
+func NewCategoriesDataSource() datasource.DataSource {
+	return &listDataSource[jamfpro.Category, category]{
+		typeName:      "categories",
+		noun:          "categories",
+		collection:    "/api/v1/categories",
+		filterExample: `name=="Lab*"`,
+		object:        NewCategoryDataSource(),
+		forState: func(ctx context.Context, client *jamfpro.Client, c jamfpro.Category) (category, bool, error) {
+			return categoryForState(&c), true, nil
+		},
+	}
+}
+
+func NewBuildingsDataSource() datasource.DataSource {
+	return &listDataSource[jamfpro.Building, building]{
+		typeName:      "buildings",
+		noun:          "buildings",
+		collection:    "/api/v1/buildings",
+		filterExample: `city=="Amsterdam"`,
+		object:        NewBuildingDataSource(),
+		forState: func(ctx context.Context, client *jamfpro.Client, b jamfpro.Building) (building, bool, error) {
+			return buildingForState(&b), true, nil
+		},
+	}
+}
+
+func NewDepartmentsDataSource() datasource.DataSource {
+	return &listDataSource[jamfpro.Department, department]{
+		typeName:      "departments",
+		noun:          "departments",
+		collection:    "/api/v1/departments",
+		filterExample: `name=="IT*"`,
+		object:        NewDepartmentDataSource(),
+		forState: func(ctx context.Context, client *jamfpro.Client, d jamfpro.Department) (department, bool, error) {
+			return departmentForState(&d), true, nil
+		},
+	}
+}
+
+func NewApiRolesDataSource() datasource.DataSource {
+	return &listDataSource[jamfpro.ApiRole, apirole]{
+		typeName:      "api_roles",
+		noun:          "API roles",
+		collection:    "/api/v1/api-roles",
+		filterExample: `displayName=="Terraform*"`,
+		object:        NewApiRoleDataSource(),
+		forState: func(ctx context.Context, client *jamfpro.Client, a jamfpro.ApiRole) (apirole, bool, error) {
+			return apiRoleForState(&a), true, nil
+		},
+	}
+}
+
+func NewComputersDataSource() datasource.DataSource {
+	return &listDataSource[inventoryComputer, computer]{
+		typeName:      "computers",
+		noun:          "computers",
+		collection:    "/api/v1/computers-inventory",
+		query:         url.Values{"section": {"GENERAL", "HARDWARE"}},
+		filterExample: `general.name=="LAB-*"`,
+		object:        NewComputerDataSource(),
+		forState: func(ctx context.Context, client *jamfpro.Client, c inventoryComputer) (computer, bool, error) {
+			id, err := strconv.Atoi(c.Id)
+			if err != nil || c.General == nil || c.Hardware == nil {
+				return computer{}, false, fmt.Errorf("invalid computer with ID %q", c.Id)
+			}
+			return computer{
+				Id:           types.Int64Value(int64(id)),
+				Name:         types.StringValue(c.General.Name),
+				SerialNumber: types.StringValue(c.Hardware.SerialNumber),
+				Udid:         types.StringValue(c.Udid),
+			}, true, nil
+		},
+	}
+}
+
+// listedGroup is a computer group as listed by the Pro API, which does not
+// include its members or criteria.
+type listedGroup struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// getListedGroup gets a listed group from the Classic API, for its members and criteria.
+func getListedGroup(ctx context.Context, client *jamfpro.Client, g listedGroup) (*jamfpro.ComputerGroup, bool, error) {
+	id, err := strconv.Atoi(g.Id)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid computer group ID %q", g.Id)
+	}
+	group, resp, err := client.ComputerGroups.GetByID(ctx, id)
+	if isNotFound(resp) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to get computer group with ID '%d': %w", id, err)
+	}
+	return group, true, nil
+}
+
+func NewComputerGroupsDataSource() datasource.DataSource {
+	return &listDataSource[listedGroup, computerGroupDataSourceModel]{
+		typeName:      "computergroups",
+		noun:          "static computer groups",
+		collection:    "/api/v2/computer-groups/static-groups",
+		filterExample: `name=="Lab*"`,
+		object:        NewComputerGroupDataSource(),
+		forState: func(ctx context.Context, client *jamfpro.Client, g listedGroup) (computerGroupDataSourceModel, bool, error) {
+			group, found, err := getListedGroup(ctx, client, g)
+			if !found {
+				return computerGroupDataSourceModel{}, false, err
+			}
+			state := computerGroupForState(group)
+			return computerGroupDataSourceModel{Id: state.Id, Name: state.Name, Computers: state.Computers}, true, nil
+		},
+	}
+}
+
+func NewSmartComputerGroupsDataSource() datasource.DataSource {
+	return &listDataSource[listedGroup, smartComputerGroupDataSourceModel]{
+		typeName:      "smartcomputergroups",
+		noun:          "smart computer groups",
+		collection:    "/api/v2/computer-groups/smart-groups",
+		filterExample: `name=="Lab*"`,
+		object:        NewSmartComputerGroupDataSource(),
+		forState: func(ctx context.Context, client *jamfpro.Client, g listedGroup) (smartComputerGroupDataSourceModel, bool, error) {
+			group, found, err := getListedGroup(ctx, client, g)
+			if !found {
+				return smartComputerGroupDataSourceModel{}, false, err
+			}
+			state := smartComputerGroupForState(group)
+			return smartComputerGroupDataSourceModel{Id: state.Id, Name: state.Name, Criteria: state.Criteria}, true, nil
+		},
+	}
+}
+
+func (d *listDataSource[T, M]) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_" + d.typeName
+}
+
+func (d *listDataSource[T, M]) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	var object datasource.SchemaResponse
+	d.object.Schema(ctx, datasource.SchemaRequest{}, &object)
+	response.Diagnostics.Append(object.Diagnostics...)
+
+	response.Schema = schema.Schema{
+		Description: fmt.Sprintf("Lists the %s matching an RSQL filter.", d.noun),
+		MarkdownDescription: fmt.Sprintf("The data source `jamfpro_%s` lists the %s matching an RSQL filter, "+
+			"following the pages of results of the Jamf Pro API to the last.", d.typeName, d.noun),
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the query.",
+				Computed:    true,
+			},
+			"filter": schema.StringAttribute{
+				Description: fmt.Sprintf("RSQL expression selecting the %s to list, in the field names of the Jamf Pro API "+
+					"(e.g. %s). All %s are listed if unset.", d.noun, d.filterExample, d.noun),
+				MarkdownDescription: fmt.Sprintf("RSQL expression selecting the %s to list, in the field names of the Jamf Pro "+
+					"API (e.g. `%s`). All %s are listed if unset.", d.noun, d.filterExample, d.noun),
+				Optional: true,
+			},
+			"sort": schema.ListAttribute{
+				Description: fmt.Sprintf("Sort criteria of the form field:asc or field:desc. The %s are sorted by "+
+					"ascending ID after these criteria.", d.noun),
+				MarkdownDescription: fmt.Sprintf("Sort criteria of the form `field:asc` or `field:desc`. The %s are "+
+					"sorted by ascending `ID` after these criteria.", d.noun),
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			d.typeName: schema.ListNestedAttribute{
+				Description: fmt.Sprintf("The %s, with the attributes of the jamfpro_%s data source.", d.noun, typeNameOf(d.object)),
+				MarkdownDescription: fmt.Sprintf("The %s, with the attributes of the `jamfpro_%s` data source.",
+					d.noun, typeNameOf(d.object)),
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: computedAttributes(object.Schema.Attributes),
+				},
+			},
+		},
+	}
+}
+
+func (d *listDataSource[T, M]) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var filter types.String
+	var sort types.List
+
+	// Read Terraform configuration data
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("filter"), &filter)...)
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("sort"), &sort)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	query := make(url.Values, len(d.query)+2)
+	for key, values := range d.query {
+		query[key] = values
+	}
+	if filter.ValueString() != "" {
+		query.Set("filter", filter.ValueString())
+	}
+	if !sort.IsNull() {
+		var sorts []string
+		response.Diagnostics.Append(sort.ElementsAs(ctx, &sorts, false)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		query["sort"] = sorts
+	}
+
+	objects, err := listAll[T](ctx, d.api, d.collection, query)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to list %s, got error: %s", d.noun, err),
+		)
+		return
+	}
+
+	models := make([]M, 0, len(objects))
+	for _, object := range objects {
+		model, found, err := d.forState(ctx, d.client, object)
+		if err != nil {
+			response.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to list %s, got error: %s", d.noun, err),
+			)
+			return
+		}
+		if found {
+			models = append(models, model)
+		}
+	}
+
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), d.collection+"?"+query.Encode())...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root(d.typeName), models)...)
+}
+
+func (d *listDataSource[T, M]) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	data, ok := request.ProviderData.(*providerData)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+	d.api = data.api
+}
+
+// typeNameOf returns the type name of a data source without the provider prefix.
+func typeNameOf(d datasource.DataSource) string {
+	var response datasource.MetadataResponse
+	d.Metadata(context.Background(), datasource.MetadataRequest{}, &response)
+	return strings.TrimPrefix(response.TypeName, "_")
+}
+
+// computedAttributes returns copies of the attributes of a data source schema
+// that are only computed, for the listed objects of a listDataSource.
+func computedAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	computed := make(map[string]schema.Attribute, len(attributes))
+	for name, attribute := range attributes {
+		switch a := attribute.(type) {
+		case schema.BoolAttribute:
+			computed[name] = schema.BoolAttribute{Description: a.Description, MarkdownDescription: a.MarkdownDescription, Computed: true}
+		case schema.Int64Attribute:
+			computed[name] = schema.Int64Attribute{Description: a.Description, MarkdownDescription: a.MarkdownDescription, Computed: true}
+		case schema.StringAttribute:
+			computed[name] = schema.StringAttribute{Description: a.Description, MarkdownDescription: a.MarkdownDescription, Computed: true}
+		case schema.SetAttribute:
+			computed[name] = schema.SetAttribute{
+				Description:         a.Description,
+				MarkdownDescription: a.MarkdownDescription,
+				ElementType:         a.ElementType,
+				Computed:            true,
+			}
+		case schema.SetNestedAttribute:
+			computed[name] = schema.SetNestedAttribute{
+				Description:         a.Description,
+				MarkdownDescription: a.MarkdownDescription,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: computedAttributes(a.NestedObject.Attributes),
+				},
+				Computed: true,
+			}
+		case schema.ListNestedAttribute:
+			computed[name] = schema.ListNestedAttribute{
+				Description:         a.Description,
+				MarkdownDescription: a.MarkdownDescription,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: computedAttributes(a.NestedObject.Attributes),
+				},
+				Computed: true,
+			}
+		default:
+			panic(fmt.Sprintf("computedAttributes: unsupported attribute %s of type %T", name, attribute))
+		}
+	}
+	return computed
+}
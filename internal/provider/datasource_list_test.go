@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestListDataSourceSchemas(t *testing.T) {
+	for _, newDataSource := range []func() datasource.DataSource{
+		NewApiRolesDataSource,
+		NewBuildingsDataSource,
+		NewCategoriesDataSource,
+		NewComputerGroupsDataSource,
+		NewComputersDataSource,
+		NewDepartmentsDataSource,
+		NewSmartComputerGroupsDataSource,
+	} {
+		d := newDataSource()
+		var response datasource.SchemaResponse
+		d.Schema(context.Background(), datasource.SchemaRequest{}, &response)
+		response.Diagnostics.Append(response.Schema.ValidateImplementation(context.Background())...)
+		if response.Diagnostics.HasError() {
+			t.Errorf("%s: %v", typeNameOf(d), response.Diagnostics)
+		}
+	}
+}
+
+func TestAccCategoriesDataSource(t *testing.T) {
+	prefix := acctest.RandString(8)
+	dataSourceName := "data.jamfpro_categories.test"
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "jamfpro_category" "test" {
+  count    = 3
+  name     = "%[1]s-${count.index}"
+  priority = count.index + 1
+}
+
+resource "jamfpro_category" "other" {
+  name     = "other-%[1]s"
+  priority = 5
+}
+
+data "jamfpro_categories" "test" {
+  filter = "name==\"%[1]s-*\""
+  sort   = ["priority:desc"]
+
+  depends_on = [jamfpro_category.test, jamfpro_category.other]
+}
+`, prefix),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "categories.#", "3"),
+					resource.TestCheckResourceAttr(dataSourceName, "categories.0.name", prefix+"-2"),
+					resource.TestCheckResourceAttr(dataSourceName, "categories.0.priority", "3"),
+					resource.TestCheckResourceAttr(dataSourceName, "categories.2.name", prefix+"-0"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "categories.2.id", "jamfpro_category.test.0", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccComputersDataSource(t *testing.T) {
+	prefix := acctest.RandString(8)
+	dataSourceName := "data.jamfpro_computers.test"
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "jamfpro_computer" "test" {
+  count         = 2
+  name          = "%[1]s-${count.index}"
+  serial_number = "%[1]s${count.index}"
+}
+
+data "jamfpro_computers" "test" {
+  filter = "general.name==\"%[1]s-*\""
+
+  depends_on = [jamfpro_computer.test]
+}
+`, prefix),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "computers.#", "2"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "computers.0.id", "jamfpro_computer.test.0", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "computers.1.serial_number", prefix+"1"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "computers.1.udid"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jc0b/go-jamfpro-api/jamfpro"
+)
+
+var _ datasource.DataSource = &SmartComputerGroupDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &SmartComputerGroupDataSource{}
+
+func NewSmartComputerGroupDataSource() datasource.DataSource {
+	return &SmartComputerGroupDataSource{}
+}
+
+type SmartComputerGroupDataSource struct {
+	client *jamfpro.Client
+}
+
+func (d *SmartComputerGroupDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_smartcomputergroup"
+}
+
+func (d *SmartComputerGroupDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+func (d *SmartComputerGroupDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Allows details of a smart computer group to be retrieved by its ID or name.",
+		MarkdownDescription: "The data source `jamfpro_smartcomputergroup` allows details of a smart computer group to be retrieved by its `ID` or name. " +
+			"Static groups are retrieved with `jamfpro_computergroup`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description:         "ID of the smart computer group.",
+				MarkdownDescription: "`ID` of the smart computer group.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the smart computer group.",
+				MarkdownDescription: "`name` of the smart computer group.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"criteria": schema.ListNestedAttribute{
+				Description: "Represents criteria by which members of a smart group are defined, in the order they are evaluated.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Represents the name of a criteria to check against",
+							Computed:    true,
+						},
+						"priority": schema.Int64Attribute{
+							Description: "Represents this elements position in the order of criteria. Counting starts at 0.",
+							Computed:    true,
+						},
+						"and_or": schema.StringAttribute{
+							Description: "Whether this criteria will be AND or ORed with the previous criteria.",
+							Computed:    true,
+						},
+						"search_type": schema.StringAttribute{
+							Description: "Represents the operator used to assess the relationship between the name and the value fields.",
+							Computed:    true,
+						},
+						"value": schema.StringAttribute{
+							Description: "Represents the value that the name criteria is checked against.",
+							Computed:    true,
+						},
+						"opening_paren": schema.BoolAttribute{
+							Description: "Represents whether this criteria contains an opening parenthesis.",
+							Computed:    true,
+						},
+						"closing_paren": schema.BoolAttribute{
+							Description: "Represents whether this criteria contains a closing parenthesis.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// smartComputerGroupDataSourceModel is the smart computer group model without the resource's timeouts.
+type smartComputerGroupDataSourceModel struct {
+	Id       types.Int64  `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Criteria types.List   `tfsdk:"criteria"`
+}
+
+func (d *SmartComputerGroupDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data smartComputerGroupDataSourceModel
+
+	// Read Terraform configuration data into the model
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var group *jamfpro.ComputerGroup
+	var resp *jamfpro.Response
+	var err error
+	description := fmt.Sprintf("ID '%d'", data.Id.ValueInt64())
+	if !data.Id.IsNull() {
+		group, resp, err = d.client.ComputerGroups.GetByID(ctx, int(data.Id.ValueInt64()))
+	} else {
+		description = fmt.Sprintf("name '%s'", data.Name.ValueString())
+		group, resp, err = d.client.ComputerGroups.GetByName(ctx, data.Name.ValueString())
+	}
+	if isNotFound(resp) {
+		response.Diagnostics.AddError(
+			"No matching smart computer group",
+			fmt.Sprintf("No smart computer group with %s exists in Jamf Pro.", description),
+		)
+		return
+	}
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get smart computer group with %s, got error: %s", description, err),
+		)
+		return
+	}
+
+	if !group.IsSmart {
+		response.Diagnostics.AddError(
+			"Not a smart computer group",
+			fmt.Sprintf("Computer group %q with ID %d is a static group. Read it with the jamfpro_computergroup "+
+				"data source instead.", group.Name, group.Id),
+		)
+		return
+	}
+
+	state := smartComputerGroupForState(group)
+	response.Diagnostics.Append(response.State.Set(ctx, smartComputerGroupDataSourceModel{
+		Id:       state.Id,
+		Name:     state.Name,
+		Criteria: state.Criteria,
+	})...)
+}
+
+func (d *SmartComputerGroupDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	data, ok := request.ProviderData.(*providerData)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
@@ -0,0 +1,417 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/jc0b/go-jamfpro-api/jamfpro"
+	"github.com/zclconf/go-cty/cty"
+	"io"
+	"math/big"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// generatedObject is an object of Jamf Pro to write a resource block for.
+type generatedObject struct {
+	id   int64
+	name string
+	// state is the model of the object's resource, as set in its state.
+	state any
+}
+
+// generatedType is a resource type the generator writes configuration for.
+type generatedType struct {
+	// name is the resource type name without the provider prefix, e.g. category.
+	name     string
+	resource func() resource.Resource
+	// list returns the objects of the type, ordered by ID.
+	list func(ctx context.Context, data *providerData) ([]generatedObject, error)
+}
+
+// generatedTypes are the resource types the generator supports, in the order
+// their resources are written.
+var generatedTypes = []generatedType{
+	{
+		name:     "category",
+		resource: NewCategoryResource,
+		list: func(ctx context.Context, data *providerData) ([]generatedObject, error) {
+			return listGenerated(ctx, data.api, "/api/v1/categories", func(c jamfpro.Category) (generatedObject, error) {
+				state := categoryForState(&c)
+				return generatedObject{id: state.Id.ValueInt64(), name: c.Name, state: state}, nil
+			})
+		},
+	},
+	{
+		name:     "building",
+		resource: NewBuildingResource,
+		list: func(ctx context.Context, data *providerData) ([]generatedObject, error) {
+			return listGenerated(ctx, data.api, "/api/v1/buildings", func(b jamfpro.Building) (generatedObject, error) {
+				state := buildingForState(&b)
+				return generatedObject{id: state.Id.ValueInt64(), name: state.Name.ValueString(), state: state}, nil
+			})
+		},
+	},
+	{
+		name:     "department",
+		resource: NewDepartmentResource,
+		list: func(ctx context.Context, data *providerData) ([]generatedObject, error) {
+			return listGenerated(ctx, data.api, "/api/v1/departments", func(d jamfpro.Department) (generatedObject, error) {
+				state := departmentForState(&d)
+				return generatedObject{id: state.Id.ValueInt64(), name: d.Name, state: state}, nil
+			})
+		},
+	},
+	{
+		name:     "api_role",
+		resource: NewApiRoleResource,
+		list: func(ctx context.Context, data *providerData) ([]generatedObject, error) {
+			return listGenerated(ctx, data.api, "/api/v1/api-roles", func(a jamfpro.ApiRole) (generatedObject, error) {
+				state := apiRoleForState(&a)
+				return generatedObject{id: state.Id.ValueInt64(), name: state.Name.ValueString(), state: state}, nil
+			})
+		},
+	},
+	{
+		name:     "api_integration",
+		resource: NewApiIntegrationResource,
+		list: func(ctx context.Context, data *providerData) ([]generatedObject, error) {
+			return listGenerated(ctx, data.api, apiIntegrationsPath, func(a apiIntegration) (generatedObject, error) {
+				state, err := apiIntegrationForState(a)
+				return generatedObject{id: state.Id.ValueInt64(), name: a.DisplayName, state: state}, err
+			})
+		},
+	},
+	{
+		name:     "computergroup",
+		resource: NewComputerGroupResource,
+		list: func(ctx context.Context, data *providerData) ([]generatedObject, error) {
+			return listGenerated(ctx, data.api, "/api/v2/computer-groups/static-groups", func(g listedGroup) (generatedObject, error) {
+				group, found, err := getListedGroup(ctx, data.client, g)
+				if !found {
+					return generatedObject{}, err
+				}
+				state := computerGroupForState(group)
+				// Configure the members by ID only, the other attributes are computed from them.
+				state.Computers = types.SetNull(types.ObjectType{AttrTypes: computerAttrTypes})
+				state.SerialNumbers = types.SetNull(types.StringType)
+				return generatedObject{id: state.Id.ValueInt64(), name: group.Name, state: state}, nil
+			})
+		},
+	},
+	{
+		name:     "smartcomputergroup",
+		resource: NewSmartComputerGroupResource,
+		list: func(ctx context.Context, data *providerData) ([]generatedObject, error) {
+			return listGenerated(ctx, data.api, "/api/v2/computer-groups/smart-groups", func(g listedGroup) (generatedObject, error) {
+				group, found, err := getListedGroup(ctx, data.client, g)
+				if !found {
+					return generatedObject{}, err
+				}
+				state := smartComputerGroupForState(group)
+				return generatedObject{id: state.Id.ValueInt64(), name: group.Name, state: state}, nil
+			})
+		},
+	},
+	{
+		name:     "computer",
+		resource: NewComputerResource,
+		list: func(ctx context.Context, data *providerData) ([]generatedObject, error) {
+			return listGenerated(ctx, data.api, "/api/v1/computers-inventory", func(c inventoryComputer) (generatedObject, error) {
+				id, err := strconv.Atoi(c.Id)
+				if err != nil {
+					return generatedObject{}, fmt.Errorf("invalid computer ID %q", c.Id)
+				}
+				computer, resp, err := data.client.Computers.GetByID(ctx, id)
+				if isNotFound(resp) {
+					return generatedObject{}, nil
+				}
+				if err != nil {
+					return generatedObject{}, fmt.Errorf("unable to get computer with ID '%d': %w", id, err)
+				}
+				state := computerForState(computer)
+				return generatedObject{id: state.Id.ValueInt64(), name: computer.Name, state: state}, nil
+			})
+		},
+	},
+}
+
+// defaultGeneratedTypes leaves out computers, which are reported by devices
+// rather than configured.
+var defaultGeneratedTypes = "category,building,department,api_role,api_integration,computergroup,smartcomputergroup"
+
+// listGenerated lists the objects of a Pro API collection and converts them
+// with forState. Objects converted to a zero generatedObject no longer exist
+// and are left out.
+func listGenerated[T any](ctx context.Context, api *apiClient, collection string, forState func(T) (generatedObject, error)) ([]generatedObject, error) {
+	listed, err := listAll[T](ctx, api, collection, nil)
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]generatedObject, 0, len(listed))
+	for _, l := range listed {
+		object, err := forState(l)
+		if err != nil {
+			return nil, err
+		}
+		if object.state != nil {
+			objects = append(objects, object)
+		}
+	}
+	return objects, nil
+}
+
+// Generate implements the generate subcommand. It writes resource and import
+// blocks for the objects of a Jamf Pro instance, which is configured through
+// the environment variables of the provider (JAMF_INSTANCE_URL, ...).
+func Generate(ctx context.Context, version string, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	out := flags.String("out", "-", "file to write the configuration to, or - for standard output")
+	typeNames := flags.String("types", defaultGeneratedTypes, "comma-separated resource types to generate, without the jamfpro_ prefix")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var types []generatedType
+	for _, name := range strings.Split(*typeNames, ",") {
+		name = strings.TrimPrefix(strings.TrimSpace(name), "jamfpro_")
+		found := false
+		for _, t := range generatedTypes {
+			if t.name == name {
+				types = append(types, t)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unsupported resource type %q", name)
+		}
+	}
+
+	data, diags := configureFromEnvironment(ctx, version)
+	if err := diagnosticsError(diags); err != nil {
+		return err
+	}
+
+	config, err := generateConfiguration(ctx, data, types)
+	if err != nil {
+		return err
+	}
+	if *out == "-" {
+		_, err = stdout.Write(config)
+		return err
+	}
+	return os.WriteFile(*out, config, 0o644)
+}
+
+// configureFromEnvironment configures the provider as it would be with an
+// empty provider block, so from its environment variables only.
+func configureFromEnvironment(ctx context.Context, version string) (*providerData, diag.Diagnostics) {
+	p := &JamfProProvider{version: version}
+	var schemaResponse provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResponse)
+	if schemaResponse.Diagnostics.HasError() {
+		return nil, schemaResponse.Diagnostics
+	}
+
+	configType := schemaResponse.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	attributes := make(map[string]tftypes.Value, len(configType.AttributeTypes))
+	for name, attributeType := range configType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	request := provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResponse.Schema,
+			Raw:    tftypes.NewValue(configType, attributes),
+		},
+	}
+	var response provider.ConfigureResponse
+	p.Configure(ctx, request, &response)
+	if response.Diagnostics.HasError() {
+		return nil, response.Diagnostics
+	}
+	data, ok := response.ResourceData.(*providerData)
+	if !ok {
+		response.Diagnostics.AddError(providerConfigurationError, "The provider could not be configured from the environment.")
+		return nil, response.Diagnostics
+	}
+	return data, response.Diagnostics
+}
+
+// diagnosticsError returns the error diagnostics as an error, or nil if there are none.
+func diagnosticsError(diags diag.Diagnostics) error {
+	var errs []error
+	for _, d := range diags.Errors() {
+		errs = append(errs, fmt.Errorf("%s: %s", d.Summary(), d.Detail()))
+	}
+	return errors.Join(errs...)
+}
+
+// generateConfiguration returns the resource and import blocks of the objects of the given types.
+func generateConfiguration(ctx context.Context, data *providerData, types []generatedType) ([]byte, error) {
+	file := hclwrite.NewEmptyFile()
+	body := file.Body()
+	labels := make(map[string]bool)
+
+	for _, t := range types {
+		var schemaResponse resource.SchemaResponse
+		t.resource().Schema(ctx, resource.SchemaRequest{}, &schemaResponse)
+		if err := diagnosticsError(schemaResponse.Diagnostics); err != nil {
+			return nil, err
+		}
+
+		objects, err := t.list(ctx, data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list jamfpro_%s objects: %w", t.name, err)
+		}
+		for _, object := range objects {
+			state := tfsdk.State{Schema: schemaResponse.Schema}
+			if err := diagnosticsError(state.Set(ctx, object.state)); err != nil {
+				return nil, fmt.Errorf("jamfpro_%s with ID %d: %w", t.name, object.id, err)
+			}
+			var stateAttributes map[string]tftypes.Value
+			if err := state.Raw.As(&stateAttributes); err != nil {
+				return nil, err
+			}
+
+			resourceType := "jamfpro_" + t.name
+			label := resourceLabel(labels, resourceType, object.name)
+			if len(body.Blocks()) > 0 {
+				body.AppendNewline()
+			}
+			block := body.AppendNewBlock("resource", []string{resourceType, label}).Body()
+
+			names := make([]string, 0, len(stateAttributes))
+			for name := range stateAttributes {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				attribute, ok := schemaResponse.Schema.Attributes[name]
+				// Leave out computed attributes, which cannot be configured, and blocks such as timeouts.
+				if !ok || !(attribute.IsRequired() || attribute.IsOptional()) {
+					continue
+				}
+				value, err := ctyValue(stateAttributes[name])
+				if err != nil {
+					return nil, fmt.Errorf("%s.%s: %w", resourceType, label, err)
+				}
+				if !value.IsNull() {
+					block.SetAttributeValue(name, value)
+				}
+			}
+
+			body.AppendNewline()
+			importBlock := body.AppendNewBlock("import", nil).Body()
+			importBlock.SetAttributeTraversal("to", hcl.Traversal{
+				hcl.TraverseRoot{Name: resourceType},
+				hcl.TraverseAttr{Name: label},
+			})
+			importBlock.SetAttributeValue("id", cty.StringVal(strconv.FormatInt(object.id, 10)))
+		}
+	}
+
+	return hclwrite.Format(file.Bytes()), nil
+}
+
+var invalidLabelCharacters = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// resourceLabel returns a unique label for the resource of an object, derived from its name.
+func resourceLabel(labels map[string]bool, resourceType string, name string) string {
+	label := strings.Trim(invalidLabelCharacters.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "_" + label
+	}
+	unique := label
+	for n := 2; labels[resourceType+"."+unique]; n++ {
+		unique = fmt.Sprintf("%s_%d", label, n)
+	}
+	labels[resourceType+"."+unique] = true
+	return unique
+}
+
+// ctyValue converts a state value to a value for hclwrite. Null attributes of
+// objects are left out, and the elements of sets are sorted so that the
+// output does not depend on the order of the objects in Jamf Pro.
+func ctyValue(value tftypes.Value) (cty.Value, error) {
+	if value.IsNull() {
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	}
+	if !value.IsKnown() {
+		return cty.NilVal, fmt.Errorf("unknown value")
+	}
+
+	switch t := value.Type().(type) {
+	case tftypes.List, tftypes.Set:
+		var elements []tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return cty.NilVal, err
+		}
+		values := make([]cty.Value, 0, len(elements))
+		for _, element := range elements {
+			v, err := ctyValue(element)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			values = append(values, v)
+		}
+		if len(values) == 0 {
+			return cty.EmptyTupleVal, nil
+		}
+		if _, ok := t.(tftypes.Set); ok {
+			sort.SliceStable(values, func(i, j int) bool {
+				return bytes.Compare(hclwrite.TokensForValue(values[i]).Bytes(), hclwrite.TokensForValue(values[j]).Bytes()) < 0
+			})
+		}
+		return cty.TupleVal(values), nil
+	case tftypes.Object:
+		var attributes map[string]tftypes.Value
+		if err := value.As(&attributes); err != nil {
+			return cty.NilVal, err
+		}
+		values := make(map[string]cty.Value, len(attributes))
+		for name, attribute := range attributes {
+			if attribute.IsNull() {
+				continue
+			}
+			v, err := ctyValue(attribute)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			values[name] = v
+		}
+		if len(values) == 0 {
+			return cty.EmptyObjectVal, nil
+		}
+		return cty.ObjectVal(values), nil
+	}
+
+	switch {
+	case value.Type().Equal(tftypes.String):
+		var s string
+		err := value.As(&s)
+		return cty.StringVal(s), err
+	case value.Type().Equal(tftypes.Bool):
+		var b bool
+		err := value.As(&b)
+		return cty.BoolVal(b), err
+	case value.Type().Equal(tftypes.Number):
+		n := new(big.Float)
+		err := value.As(&n)
+		return cty.NumberVal(n), err
+	}
+	return cty.NilVal, fmt.Errorf("unsupported value type %s", value.Type())
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceLabel(t *testing.T) {
+	labels := make(map[string]bool)
+	for _, tc := range []struct {
+		resourceType string
+		name         string
+		want         string
+	}{
+		{"jamfpro_category", "Productivity Apps", "productivity_apps"},
+		{"jamfpro_category", "productivity-apps", "productivity_apps_2"},
+		{"jamfpro_building", "Productivity Apps", "productivity_apps"},
+		{"jamfpro_category", "2nd Floor", "_2nd_floor"},
+		{"jamfpro_category", "Ünïcode!", "n_code"},
+		{"jamfpro_category", "???", "_"},
+	} {
+		if got := resourceLabel(labels, tc.resourceType, tc.name); got != tc.want {
+			t.Errorf("resourceLabel(%q, %q) = %q, want %q", tc.resourceType, tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestAccGenerate(t *testing.T) {
+	name := acctest.RandString(12)
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "jamfpro_category" "test" {
+  name     = "%[1]s"
+  priority = 7
+}
+
+resource "jamfpro_smartcomputergroup" "test" {
+  name     = "%[1]s"
+  criteria = [
+	{
+		and_or = "and"
+		name = "Application Title"
+		priority = 0
+		search_type = "is"
+		value = "Safari.app"
+	},
+  ]
+}
+`, name),
+				Check: func(s *terraform.State) error {
+					var out bytes.Buffer
+					if err := Generate(context.Background(), "test", []string{"-types", "category,jamfpro_smartcomputergroup"}, &out); err != nil {
+						return err
+					}
+					config := out.String()
+
+					if _, diags := hclparse.NewParser().ParseHCL(out.Bytes(), "generated.tf"); diags.HasErrors() {
+						return fmt.Errorf("generated configuration does not parse: %s\n%s", diags, config)
+					}
+
+					label := strings.ToLower(name)
+					for _, want := range []string{
+						fmt.Sprintf("resource \"jamfpro_category\" \"%s\" {\n  name     = \"%s\"\n  priority = 7\n}", label, name),
+						fmt.Sprintf("import {\n  to = jamfpro_category.%s\n  id = \"%s\"\n}", label, s.RootModule().Resources["jamfpro_category.test"].Primary.ID),
+						fmt.Sprintf("resource \"jamfpro_smartcomputergroup\" \"%s\" {", label),
+						"search_type   = \"is\"",
+						fmt.Sprintf("import {\n  to = jamfpro_smartcomputergroup.%s\n  id = \"%s\"\n}", label, s.RootModule().Resources["jamfpro_smartcomputergroup.test"].Primary.ID),
+					} {
+						if !strings.Contains(config, want) {
+							return fmt.Errorf("generated configuration does not contain %q:\n%s", want, config)
+						}
+					}
+					if strings.Contains(config, "jamfpro_building") {
+						return fmt.Errorf("generated configuration contains types that were not requested:\n%s", config)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// requestLimiter bounds the rate and the number of concurrent requests sent
+// to Jamf Pro, across all resources and data sources of a provider
+// configuration. It is safe for concurrent use.
+type requestLimiter struct {
+	// slots holds a value for every request in flight. It is nil when concurrency is unlimited.
+	slots chan struct{}
+
+	mu sync.Mutex
+	// interval is the minimum time between the start of two requests. Zero means no rate limit.
+	interval time.Duration
+	next     time.Time
+}
+
+// newRequestLimiter returns a limiter allowing maxConcurrent requests at a time
+// and perSecond requests per second. A limit of zero means unlimited.
+func newRequestLimiter(maxConcurrent int, perSecond float64) *requestLimiter {
+	l := &requestLimiter{}
+	if maxConcurrent > 0 {
+		l.slots = make(chan struct{}, maxConcurrent)
+	}
+	if perSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+	return l
+}
+
+// acquire waits until a request may be sent. The returned function must be
+// called once the request has completed.
+func (l *requestLimiter) acquire(ctx context.Context) (release func(), err error) {
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	var once sync.Once
+	release = func() {
+		once.Do(func() {
+			if l.slots != nil {
+				<-l.slots
+			}
+		})
+	}
+
+	if l.interval > 0 {
+		l.mu.Lock()
+		start := time.Now()
+		if l.next.After(start) {
+			start = l.next
+		}
+		l.next = start.Add(l.interval)
+		l.mu.Unlock()
+
+		if wait := time.Until(start); wait > 0 {
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				release()
+				return nil, ctx.Err()
+			}
+		}
+	}
+	return release, nil
+}
+
+// limitTransport sends requests within the limits of a requestLimiter. A
+// request is in flight until its response body is closed.
+type limitTransport struct {
+	base    http.RoundTripper
+	limiter *requestLimiter
+}
+
+func newLimitTransport(base http.RoundTripper, limiter *requestLimiter) *limitTransport {
+	return &limitTransport{base: base, limiter: limiter}
+}
+
+func (t *limitTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	release, err := t.limiter.acquire(request.Context())
+	if err != nil {
+		return nil, err
+	}
+	response, err := t.base.RoundTrip(request)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	response.Body = &onCloseBody{ReadCloser: response.Body, onClose: release}
+	return response, nil
+}
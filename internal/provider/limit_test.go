@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimitTransportMaxConcurrent(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newLimitTransport(defaultTransport, newRequestLimiter(3, 0))}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 12; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := client.Get(server.URL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			response.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if got := maxInFlight.Load(); got != 3 {
+		t.Errorf("%d requests in flight at most, want 3", got)
+	}
+}
+
+func TestLimitTransportPerClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	// Two provider configurations, allowing one request in flight each.
+	routes := &clientTransports{base: defaultTransport, transports: map[string]http.RoundTripper{}}
+	first := routes.add(newLimitTransport(defaultTransport, newRequestLimiter(1, 0)))
+	second := routes.add(newLimitTransport(defaultTransport, newRequestLimiter(1, 0)))
+	client := &http.Client{Transport: routes}
+	get := func(key string) (*http.Response, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		request, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		request.Header.Set(clientTransportHeader, key)
+		return client.Do(request)
+	}
+
+	inFlight, err := get(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer inFlight.Body.Close()
+
+	if response, err := get(first); err == nil {
+		response.Body.Close()
+		t.Error("sent a request beyond the limit of the first configuration")
+	}
+	response, err := get(second)
+	if err != nil {
+		t.Fatalf("the second configuration shares the limit of the first: %s", err)
+	}
+	response.Body.Close()
+}
+
+func TestLimitTransportRequestsPerSecond(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newLimitTransport(defaultTransport, newRequestLimiter(0, 20))}
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		response, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		response.Body.Close()
+	}
+
+	// The first request starts right away, the others 50ms apart.
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("5 requests took %s, want at least 200ms at 20 requests per second", elapsed)
+	}
+}
+
+func TestRequestLimiterCancel(t *testing.T) {
+	limiter := newRequestLimiter(1, 0)
+	release, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.acquire(ctx); err == nil {
+		t.Error("acquired a slot beyond the limit")
+	}
+
+	release()
+	release()
+	if _, err := limiter.acquire(context.Background()); err != nil {
+		t.Errorf("slot was not released: %s", err)
+	}
+}
@@ -3,31 +3,64 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/providervalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jc0b/go-jamfpro-api/jamfpro"
-	"log"
+	"net/http"
 	"os"
+	"strconv"
 	"time"
 )
 
 var providerConfigurationError = "Jamf Pro provider configuration error"
-var localSessionStorageFile = "/tmp/jamf-tf-session"
 
 var _ provider.Provider = &JamfProProvider{}
+var _ provider.ProviderWithConfigValidators = &JamfProProvider{}
 
 type JamfProProvider struct {
 	version string
 }
 
 type JamfProProviderModel struct {
-	InstanceURL  types.String `tfsdk:"instance_url"`
-	ClientID     types.String `tfsdk:"client_id"`
-	ClientSecret types.String `tfsdk:"client_secret"`
+	InstanceURL        types.String  `tfsdk:"instance_url"`
+	ClientID           types.String  `tfsdk:"client_id"`
+	ClientSecret       types.String  `tfsdk:"client_secret"`
+	Username           types.String  `tfsdk:"username"`
+	Password           types.String  `tfsdk:"password"`
+	TokenCacheDir      types.String  `tfsdk:"token_cache_dir"`
+	DisableTokenCache  types.Bool    `tfsdk:"disable_token_cache"`
+	CACertificatePEM   types.String  `tfsdk:"ca_certificate_pem"`
+	CACertificateFile  types.String  `tfsdk:"ca_certificate_file"`
+	ProxyURL           types.String  `tfsdk:"proxy_url"`
+	InsecureSkipVerify types.Bool    `tfsdk:"insecure_skip_verify"`
+	RequestTimeout     types.String  `tfsdk:"request_timeout"`
+	RetryMaxAttempts   types.Int64   `tfsdk:"retry_max_attempts"`
+	RetryMaxBackoff    types.String  `tfsdk:"retry_max_backoff"`
+	RetryStatusCodes   types.List    `tfsdk:"retry_status_codes"`
+	MaxConcurrent      types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond  types.Float64 `tfsdk:"requests_per_second"`
+	ReadOnly           types.Bool    `tfsdk:"read_only"`
+}
+
+// providerData is handed to resources and data sources when they are configured.
+type providerData struct {
+	client *jamfpro.Client
+	// retry is also used by resources waiting for their changes to propagate.
+	retry retryPolicy
+	// readOnly makes resources refuse to create, update or delete objects.
+	readOnly bool
+	// api covers the endpoints that client does not.
+	api *apiClient
 }
 
 func (j JamfProProvider) Metadata(ctx context.Context, request provider.MetadataRequest, response *provider.MetadataResponse) {
@@ -59,12 +92,131 @@ func (j JamfProProvider) Schema(ctx context.Context, request provider.SchemaRequ
 				MarkdownDescription: "The Client Secret of an API Client. Can also be set with the `JAMF_CLIENT_SECRET`" +
 					"environment variable. Must be used in conjunction with a matching Client ID.",
 			},
+			"username": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   false,
+				Description: "The username of a Jamf Pro user account.",
+				MarkdownDescription: "The username of a Jamf Pro user account, authenticated with basic authentication " +
+					"instead of an API Client. Can also be set with the `JAMF_USERNAME` environment variable. Must be " +
+					"used in conjunction with a matching password, and cannot be combined with API Client credentials.",
+			},
+			"password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The password of a Jamf Pro user account.",
+				MarkdownDescription: "The password of a Jamf Pro user account. Can also be set with the " +
+					"`JAMF_PASSWORD` environment variable. Must be used in conjunction with a matching username.",
+			},
+			"token_cache_dir": schema.StringAttribute{
+				Optional:    true,
+				Description: "Directory in which session tokens are cached between runs.",
+				MarkdownDescription: "Directory in which session tokens are cached between runs, in one file per " +
+					"instance URL and client, readable only by the current user. Defaults to a " +
+					"`terraform-provider-jamfpro` directory in the user cache directory. Can also be set with the " +
+					"`JAMF_TOKEN_CACHE_DIR` environment variable.",
+			},
+			"disable_token_cache": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Disables the session token cache.",
+				MarkdownDescription: "Disables the session token cache, so that a new token is requested on every run. " +
+					"Can also be set with the `JAMF_DISABLE_TOKEN_CACHE` environment variable.",
+			},
+			"ca_certificate_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded CA certificates to trust in addition to the system ones.",
+				MarkdownDescription: "PEM encoded CA certificates to trust in addition to the system ones, for instances " +
+					"using an internal CA. Can also be set with the `JAMF_CA_CERTIFICATE_PEM` environment variable.",
+			},
+			"ca_certificate_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a file of PEM encoded CA certificates to trust in addition to the system ones.",
+				MarkdownDescription: "Path to a file of PEM encoded CA certificates to trust in addition to the system " +
+					"ones. Can also be set with the `JAMF_CA_CERTIFICATE_FILE` environment variable.",
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the proxy used to reach the Jamf Pro instance.",
+				MarkdownDescription: "URL of the proxy used to reach the Jamf Pro instance (e.g. http://proxy.example.com:3128). " +
+					"Can also be set with the `JAMF_PROXY_URL` environment variable. Defaults to the proxy set by the " +
+					"`HTTPS_PROXY` and `NO_PROXY` environment variables.",
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Disables verification of the TLS certificate of the Jamf Pro instance.",
+				MarkdownDescription: "Disables verification of the TLS certificate of the Jamf Pro instance. Only use " +
+					"this for testing. Can also be set with the `JAMF_INSECURE_SKIP_VERIFY` environment variable.",
+			},
+			"request_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "Maximum duration of a single API request.",
+				MarkdownDescription: "Maximum duration of a single API request, as a duration string (e.g. `30s` or " +
+					"`2m`). Unlimited by default. Can also be set with the `JAMF_REQUEST_TIMEOUT` environment variable.",
+			},
+			"retry_max_attempts": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of attempts of an API request, the first one included.",
+				MarkdownDescription: "Maximum number of attempts of an API request, the first one included. Requests are " +
+					"retried when they fail with one of the `retry_status_codes`, or with a network error if they are " +
+					"idempotent. Also bounds how often a resource checks that a change has propagated, except for computer " +
+					"groups, which are checked until the timeout of the operation. Defaults to 5. " +
+					"Set to 1 to disable retries. Can also be set with the `JAMF_RETRY_MAX_ATTEMPTS` environment variable.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				Optional:    true,
+				Description: "Maximum wait between two attempts of an API request.",
+				MarkdownDescription: "Maximum wait between two attempts of an API request, as a duration string (e.g. " +
+					"`30s`). The wait starts at one second and doubles with every attempt. A longer wait requested by " +
+					"the server with a `Retry-After` header is always honoured. Defaults to `30s`. Can also be set with " +
+					"the `JAMF_RETRY_MAX_BACKOFF` environment variable.",
+			},
+			"retry_status_codes": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.Int64Type,
+				Description: "HTTP status codes of responses that are retried.",
+				MarkdownDescription: "HTTP status codes of responses that are retried. Requests that are not idempotent, " +
+					"such as creations, are only retried on `429` and `503`, which Jamf Pro sends without processing them. " +
+					"Defaults to `[429, 502, 503, 504]`.",
+				Validators: []validator.List{
+					listvalidator.ValueInt64sAre(int64validator.Between(400, 599)),
+				},
+			},
+			"read_only": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Refuses to create, update or delete objects in Jamf Pro.",
+				MarkdownDescription: "Refuses to create, update or delete objects in Jamf Pro. Data sources and refreshes " +
+					"work as usual, so plans can be made with credentials that must not change anything, but applying a " +
+					"change fails before any write request is sent. Can also be set with the `JAMF_READ_ONLY` " +
+					"environment variable.",
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of API requests in flight at the same time.",
+				MarkdownDescription: "Maximum number of API requests in flight at the same time, shared by all resources " +
+					"and data sources of the provider configuration. Unlimited by default. Can also be set with the `JAMF_MAX_CONCURRENT_REQUESTS` " +
+					"environment variable.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Maximum number of API requests started per second.",
+				MarkdownDescription: "Maximum number of API requests started per second, shared by all resources and " +
+					"data sources of the provider configuration. Retried requests count as well. Unlimited by default. Can also be set with the " +
+					"`JAMF_REQUESTS_PER_SECOND` environment variable.",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.01),
+				},
+			},
 		},
 	}
 }
 
 func (j JamfProProvider) Configure(ctx context.Context, request provider.ConfigureRequest, response *provider.ConfigureResponse) {
-	fmt.Println("Configuring Jamf Pro Provider...")
+	tflog.Info(ctx, "Configuring Jamf Pro provider")
 	var data JamfProProviderModel
 
 	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
@@ -129,73 +281,383 @@ func (j JamfProProvider) Configure(ctx context.Context, request provider.Configu
 		clientSecret = data.ClientSecret.ValueString()
 	}
 
-	var apiClient = (clientId != "") == (clientSecret != "")
+	// Username & Password
+	var username string
+	var password string
+	if data.Username.IsUnknown() {
+		response.Diagnostics.AddWarning(
+			providerConfigurationError,
+			"Cannot use unknown value as Username",
+		)
+		return
+	}
+
+	if data.Password.IsUnknown() {
+		response.Diagnostics.AddWarning(
+			providerConfigurationError,
+			"Cannot use unknown value as Password",
+		)
+		return
+	}
+
+	if data.Username.IsNull() {
+		username = os.Getenv("JAMF_USERNAME")
+	} else {
+		username = data.Username.ValueString()
+	}
 
-	if !apiClient {
+	if data.Password.IsNull() {
+		password = os.Getenv("JAMF_PASSWORD")
+	} else {
+		password = data.Password.ValueString()
+	}
+
+	var apiClient = clientId != "" || clientSecret != ""
+	var userAccount = username != "" || password != ""
+
+	switch {
+	case apiClient && userAccount:
+		response.Diagnostics.AddError(
+			providerConfigurationError,
+			"Only one authentication method can be used: either API Client credentials (client_id and "+
+				"client_secret) or a user account (username and password).")
+		return
+	case apiClient && (clientId == "" || clientSecret == ""):
+		response.Diagnostics.AddError(
+			providerConfigurationError,
+			"API Client credentials require both a Client ID and a Client Secret.")
+		return
+	case userAccount && (username == "" || password == ""):
 		response.Diagnostics.AddError(
 			providerConfigurationError,
-			"You must supply API Client credentials to authenticate.")
+			"User account credentials require both a username and a password.")
+		return
+	case !apiClient && !userAccount:
+		response.Diagnostics.AddError(
+			providerConfigurationError,
+			"You must supply API Client credentials or a username and password to authenticate.")
+		return
 	}
 
-	userAgent := fmt.Sprintf("terraform-provider-jamfpro/%s", j.version)
+	// Token cache
+	cache := tokenCache{dir: defaultTokenCacheDir()}
+	if dir := stringFromConfigOrEnv(data.TokenCacheDir, "JAMF_TOKEN_CACHE_DIR"); dir != "" {
+		cache.dir = dir
+	}
 
-	jamfSession := ""
-	token, err := os.ReadFile(localSessionStorageFile)
+	disableTokenCache, err := boolFromConfigOrEnv(data.DisableTokenCache, "JAMF_DISABLE_TOKEN_CACHE")
+	if err != nil {
+		response.Diagnostics.AddError(providerConfigurationError, err.Error())
+		return
+	}
+	if disableTokenCache {
+		cache = tokenCache{}
+	}
 
-	// if the file is populated, read it and pass it
-	// else, its empty string
+	// Transport
+	if data.CACertificatePEM.IsUnknown() || data.CACertificateFile.IsUnknown() || data.ProxyURL.IsUnknown() ||
+		data.InsecureSkipVerify.IsUnknown() || data.RequestTimeout.IsUnknown() {
+		response.Diagnostics.AddWarning(
+			providerConfigurationError,
+			"Cannot use unknown values in the CA certificate, proxy or timeout settings",
+		)
+		return
+	}
 
-	if err == nil {
-		info, err := os.Stat(localSessionStorageFile)
-		if info.ModTime().Before(time.Now().Add(time.Minute*30)) && err == nil {
-			jamfSession = string(token)
+	var transportConfig transportConfig
+	transportConfig.caCertificatePEM = stringFromConfigOrEnv(data.CACertificatePEM, "JAMF_CA_CERTIFICATE_PEM")
+	if caFile := stringFromConfigOrEnv(data.CACertificateFile, "JAMF_CA_CERTIFICATE_FILE"); caFile != "" {
+		content, err := os.ReadFile(caFile)
+		if err != nil {
+			response.Diagnostics.AddError(
+				providerConfigurationError,
+				fmt.Sprintf("Unable to read CA certificate file: %s", err))
+			return
 		}
+		transportConfig.caCertificatePEM += "\n" + string(content)
 	}
 
-	var c *jamfpro.Client
+	transportConfig.proxyURL = stringFromConfigOrEnv(data.ProxyURL, "JAMF_PROXY_URL")
 
-	c, err = jamfpro.NewClient(clientId, clientSecret, InstanceURL, jamfSession)
+	transportConfig.insecureSkipVerify, err = boolFromConfigOrEnv(data.InsecureSkipVerify, "JAMF_INSECURE_SKIP_VERIFY")
 	if err != nil {
-		response.Diagnostics.AddError(
-			"Unable to create client",
-			"Unable to create OAuth client:\n\n"+err.Error())
+		response.Diagnostics.AddError(providerConfigurationError, err.Error())
+		return
+	}
+	if transportConfig.insecureSkipVerify {
+		tflog.Warn(ctx, "TLS certificate verification of the Jamf Pro instance is disabled")
 	}
 
-	if c.GetSessionToken() != jamfSession {
-		f, err := os.Create(localSessionStorageFile)
-		if err != nil {
-			log.Fatal(err)
+	if timeout := stringFromConfigOrEnv(data.RequestTimeout, "JAMF_REQUEST_TIMEOUT"); timeout != "" {
+		transportConfig.requestTimeout, err = time.ParseDuration(timeout)
+		if err != nil || transportConfig.requestTimeout <= 0 {
+			response.Diagnostics.AddError(
+				providerConfigurationError,
+				fmt.Sprintf("Request timeout must be a positive duration such as 30s, got %q", timeout))
+			return
 		}
+	}
 
-		_, err = f.WriteString(c.GetSessionToken() + "\n")
-		if err != nil {
-			log.Fatal(err)
+	baseTransport, err := newBaseTransport(transportConfig)
+	if err != nil {
+		response.Diagnostics.AddError(providerConfigurationError, err.Error())
+		return
+	}
+
+	// Retries
+	if data.RetryMaxAttempts.IsUnknown() || data.RetryMaxBackoff.IsUnknown() || data.RetryStatusCodes.IsUnknown() {
+		response.Diagnostics.AddWarning(
+			providerConfigurationError,
+			"Cannot use unknown values in the retry settings",
+		)
+		return
+	}
+
+	retry := defaultRetryPolicy()
+	if !data.RetryMaxAttempts.IsNull() {
+		retry.maxAttempts = int(data.RetryMaxAttempts.ValueInt64())
+	} else if env := os.Getenv("JAMF_RETRY_MAX_ATTEMPTS"); env != "" {
+		retry.maxAttempts, err = strconv.Atoi(env)
+		if err != nil || retry.maxAttempts < 1 {
+			response.Diagnostics.AddError(
+				providerConfigurationError,
+				fmt.Sprintf("JAMF_RETRY_MAX_ATTEMPTS must be a positive number, got %q", env))
+			return
 		}
-		err = f.Sync()
-		if err != nil {
+	}
+
+	if backoff := stringFromConfigOrEnv(data.RetryMaxBackoff, "JAMF_RETRY_MAX_BACKOFF"); backoff != "" {
+		maxBackoff, err := time.ParseDuration(backoff)
+		if err != nil || maxBackoff <= 0 {
+			response.Diagnostics.AddError(
+				providerConfigurationError,
+				fmt.Sprintf("Retry max backoff must be a positive duration such as 30s, got %q", backoff))
+			return
+		}
+		retry = newRetryPolicy(retry.maxAttempts, maxBackoff, defaultRetryStatusCodes)
+	}
+
+	if !data.RetryStatusCodes.IsNull() {
+		var statusCodes []int
+		response.Diagnostics.Append(data.RetryStatusCodes.ElementsAs(ctx, &statusCodes, false)...)
+		if response.Diagnostics.HasError() {
 			return
 		}
+		retry = newRetryPolicy(retry.maxAttempts, retry.maxBackoff, statusCodes)
+	}
+
+	// Read-only mode
+	if data.ReadOnly.IsUnknown() {
+		response.Diagnostics.AddWarning(
+			providerConfigurationError,
+			"Cannot use unknown value as read_only",
+		)
+		return
+	}
+
+	readOnly, err := boolFromConfigOrEnv(data.ReadOnly, "JAMF_READ_ONLY")
+	if err != nil {
+		response.Diagnostics.AddError(providerConfigurationError, err.Error())
+		return
+	}
+
+	// Rate limits
+	if data.MaxConcurrent.IsUnknown() || data.RequestsPerSecond.IsUnknown() {
+		response.Diagnostics.AddWarning(
+			providerConfigurationError,
+			"Cannot use unknown values in the request limits",
+		)
+		return
+	}
+
+	var maxConcurrent int
+	if !data.MaxConcurrent.IsNull() {
+		maxConcurrent = int(data.MaxConcurrent.ValueInt64())
+	} else if env := os.Getenv("JAMF_MAX_CONCURRENT_REQUESTS"); env != "" {
+		maxConcurrent, err = strconv.Atoi(env)
+		if err != nil || maxConcurrent < 1 {
+			response.Diagnostics.AddError(
+				providerConfigurationError,
+				fmt.Sprintf("JAMF_MAX_CONCURRENT_REQUESTS must be a positive number, got %q", env))
+			return
+		}
+	}
+
+	var requestsPerSecond float64
+	if !data.RequestsPerSecond.IsNull() {
+		requestsPerSecond = data.RequestsPerSecond.ValueFloat64()
+	} else if env := os.Getenv("JAMF_REQUESTS_PER_SECOND"); env != "" {
+		requestsPerSecond, err = strconv.ParseFloat(env, 64)
+		if err != nil || requestsPerSecond <= 0 {
+			response.Diagnostics.AddError(
+				providerConfigurationError,
+				fmt.Sprintf("JAMF_REQUESTS_PER_SECOND must be a positive number, got %q", env))
+			return
+		}
+	}
+
+	// Every API request of this configuration counts towards the same limits.
+	// Other configurations, such as aliases, have their own, as useTransport
+	// routes the requests of each client apart.
+	limitedTransport := newLimitTransport(baseTransport, newRequestLimiter(maxConcurrent, requestsPerSecond))
+
+	userAgent := fmt.Sprintf("terraform-provider-jamfpro/%s", j.version)
+
+	// Token requests bypass the limits, so that refreshing a token never waits
+	// for a slot held by the requests waiting for the token. tokenSource sends
+	// them one at a time.
+	tokenHTTPClient := &http.Client{Transport: newRetryTransport(baseTransport, retry)}
+	tokens := &tokenSource{}
+	var principal string
+	if apiClient {
+		principal = clientId
+		tokens.fetch = func(ctx context.Context) (sessionToken, error) {
+			return fetchOAuthToken(ctx, tokenHTTPClient, InstanceURL, clientId, clientSecret)
+		}
+	} else {
+		principal = "user:" + username
+		tokens.fetch = func(ctx context.Context) (sessionToken, error) {
+			return fetchBasicToken(ctx, tokenHTTPClient, InstanceURL, username, password)
+		}
+		tokens.keepAlive = func(ctx context.Context, token sessionToken) (sessionToken, error) {
+			return keepAliveToken(ctx, tokenHTTPClient, InstanceURL, token)
+		}
+		tokens.invalidate = func(ctx context.Context, token sessionToken) {
+			if err := invalidateToken(ctx, tokenHTTPClient, InstanceURL, token); err != nil {
+				tflog.Warn(ctx, "Unable to invalidate the previous session token", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		}
+	}
+	tokens.refreshed = func(ctx context.Context, token sessionToken) {
+		if err := cache.store(InstanceURL, principal, token); err != nil {
+			tflog.Warn(ctx, "Unable to cache the session token", map[string]interface{}{
+				"token_cache_dir": cache.dir,
+				"error":           err.Error(),
+			})
+		}
+	}
+	if cached, ok := cache.load(InstanceURL, principal); ok {
+		tokens.token = cached
+	}
+
+	token, err := tokens.Token(ctx)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to create client",
+			"Unable to obtain a session token:\n\n"+err.Error())
+		return
+	}
+
+	// Every request is authenticated by the transport, which keeps the token
+	// fresh during long applies, and retried according to the retry policy.
+	transport, err := newAuthTransport(limitedTransport, InstanceURL, tokens)
+	if err != nil {
+		response.Diagnostics.AddError(
+			providerConfigurationError,
+			fmt.Sprintf("Invalid Instance URL: %s", err))
+		return
+	}
+	retryingTransport := newRetryTransport(transport, retry)
+
+	c, err := jamfpro.NewClient(clientId, clientSecret, InstanceURL, token.Token)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to create client",
+			"Unable to create OAuth client:\n\n"+err.Error())
+		return
 	}
 
 	c.ExtraHeader["User-Agent"] = userAgent
+	useTransport(c, retryingTransport)
+
+	configured := &providerData{
+		client:   c,
+		retry:    retry,
+		readOnly: readOnly,
+		api:      newAPIClient(InstanceURL, retryingTransport, userAgent),
+	}
+	response.DataSourceData = configured
+	response.ResourceData = configured
+}
 
-	response.DataSourceData = c
-	response.ResourceData = c
+// stringFromConfigOrEnv returns a configured string, or the value of an
+// environment variable when it is not set in the configuration.
+func stringFromConfigOrEnv(value types.String, env string) string {
+	if value.IsNull() {
+		return os.Getenv(env)
+	}
+	return value.ValueString()
+}
+
+// boolFromConfigOrEnv returns a configured boolean, or the value of an
+// environment variable when it is not set in the configuration.
+func boolFromConfigOrEnv(value types.Bool, env string) (bool, error) {
+	if !value.IsNull() {
+		return value.ValueBool(), nil
+	}
+	if os.Getenv(env) == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(os.Getenv(env))
+	if err != nil {
+		return false, fmt.Errorf("invalid value for %s: %w", env, err)
+	}
+	return b, nil
+}
+
+func (j JamfProProvider) ConfigValidators(ctx context.Context) []provider.ConfigValidator {
+	return []provider.ConfigValidator{
+		providervalidator.Conflicting(
+			path.MatchRoot("client_id"),
+			path.MatchRoot("username"),
+		),
+		providervalidator.RequiredTogether(
+			path.MatchRoot("client_id"),
+			path.MatchRoot("client_secret"),
+		),
+		providervalidator.RequiredTogether(
+			path.MatchRoot("username"),
+			path.MatchRoot("password"),
+		),
+		providervalidator.Conflicting(
+			path.MatchRoot("ca_certificate_pem"),
+			path.MatchRoot("ca_certificate_file"),
+		),
+	}
 }
 
 func (j JamfProProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewApiRoleDataSource,
+		NewApiRolePrivilegesDataSource,
+		NewApiRolesDataSource,
+		NewBuildingDataSource,
+		NewBuildingsDataSource,
+		NewCategoriesDataSource,
 		NewCategoryDataSource,
 		NewComputerDataSource,
+		NewComputerGroupDataSource,
+		NewComputerGroupsDataSource,
+		NewComputerInventoryDataSource,
+		NewComputersDataSource,
+		NewDepartmentDataSource,
+		NewDepartmentsDataSource,
+		NewSmartComputerGroupDataSource,
+		NewSmartComputerGroupsDataSource,
 	}
 }
 
 func (j JamfProProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewApiIntegrationCredentialsResource,
+		NewApiIntegrationResource,
 		NewApiRoleResource,
 		NewBuildingResource,
 		NewCategoryResource,
 		NewComputerGroupResource,
+		NewComputerGroupMembershipResource,
 		NewComputerResource,
 		NewDepartmentResource,
 		NewSmartComputerGroupResource,
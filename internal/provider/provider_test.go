@@ -1,11 +1,21 @@
 package provider
 
 import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
 	"os"
+	"regexp"
+	"strconv"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/jc0b/go-jamfpro-api/jamfpro"
+	"github.com/jc0b/terraform-provider-jamfpro/internal/jamfmock"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -16,6 +26,68 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"jamfpro": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccRun runs testCase against the Jamf Pro instance configured in the
+// environment when TF_ACC is set, and against an in-process mock server otherwise.
+func testAccRun(t *testing.T, testCase resource.TestCase) {
+	if os.Getenv(resource.EnvTfAcc) != "" {
+		resource.Test(t, testCase)
+		return
+	}
+
+	testAccMockServer(t)
+	resource.UnitTest(t, testCase)
+}
+
+// testAccMockServer starts a mock Jamf Pro server for the duration of the test
+// and points the provider at it.
+func testAccMockServer(t *testing.T) *jamfmock.Server {
+	server := jamfmock.NewServer()
+	t.Cleanup(server.Close)
+
+	t.Setenv("JAMF_INSTANCE_URL", server.URL)
+	t.Setenv("JAMF_CLIENT_ID", jamfmock.ClientID)
+	t.Setenv("JAMF_CLIENT_SECRET", jamfmock.ClientSecret)
+	t.Setenv("JAMF_TOKEN_CACHE_DIR", t.TempDir())
+	// The mock server has no real propagation delays to wait out.
+	t.Setenv("JAMF_RETRY_MAX_BACKOFF", "100ms")
+
+	return server
+}
+
+// testAccJamfProClient returns a client for the Jamf Pro instance under test,
+// used to change objects behind the provider's back.
+func testAccJamfProClient(t *testing.T) *jamfpro.Client {
+	t.Helper()
+	instanceURL := os.Getenv("JAMF_INSTANCE_URL")
+	clientId := os.Getenv("JAMF_CLIENT_ID")
+	clientSecret := os.Getenv("JAMF_CLIENT_SECRET")
+
+	token, err := fetchOAuthToken(context.Background(), &http.Client{Transport: defaultTransport}, instanceURL, clientId, clientSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := jamfpro.NewClient(clientId, clientSecret, instanceURL, token.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// testAccCheckResourceDisappears deletes the object of a resource outside of Terraform.
+func testAccCheckResourceDisappears(t *testing.T, resourceName string, delete func(ctx context.Context, client *jamfpro.Client, id int) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("invalid ID of %s: %w", resourceName, err)
+		}
+		return delete(context.Background(), testAccJamfProClient(t), id)
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if !isClientIdSet() {
 		t.Fatal("JAMF_CLIENT_ID environment variable must be set for acceptance tests")
@@ -48,3 +120,113 @@ func isInstanceURLSet() bool {
 	}
 	return false
 }
+
+func TestAccProvider_userAccount(t *testing.T) {
+	testAccMockServer(t)
+	t.Setenv("JAMF_CLIENT_ID", "")
+	t.Setenv("JAMF_CLIENT_SECRET", "")
+	t.Setenv("JAMF_USERNAME", jamfmock.Username)
+	t.Setenv("JAMF_PASSWORD", jamfmock.Password)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "jamfpro_department" "test" {
+  name = "User Account Department"
+}`,
+				Check: resource.TestCheckResourceAttr(
+					"jamfpro_department.test", "name", "User Account Department"),
+			},
+		},
+	})
+}
+
+func TestAccProvider_conflictingCredentials(t *testing.T) {
+	testAccMockServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "jamfpro" {
+  client_id     = "id"
+  client_secret = "secret"
+  username      = "user"
+  password      = "password"
+}
+
+resource "jamfpro_department" "test" {
+  name = "Conflicting Credentials Department"
+}`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_customCA(t *testing.T) {
+	server := jamfmock.NewTLSServer()
+	t.Cleanup(server.Close)
+
+	t.Setenv("JAMF_INSTANCE_URL", server.URL)
+	t.Setenv("JAMF_CLIENT_ID", jamfmock.ClientID)
+	t.Setenv("JAMF_CLIENT_SECRET", jamfmock.ClientSecret)
+	t.Setenv("JAMF_TOKEN_CACHE_DIR", t.TempDir())
+	t.Setenv("JAMF_CA_CERTIFICATE_PEM", string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})))
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "jamfpro_category" "test" {
+  name     = "Custom CA Category"
+  priority = 5
+}`,
+				Check: resource.TestCheckResourceAttr(
+					"jamfpro_category.test", "name", "Custom CA Category"),
+			},
+		},
+	})
+}
+
+func TestAccProvider_readOnly(t *testing.T) {
+	config := func(readOnly bool, name string) string {
+		return fmt.Sprintf(`
+provider "jamfpro" {
+  read_only = %t
+}
+
+resource "jamfpro_category" "test" {
+  name     = %q
+  priority = 5
+}`, readOnly, name)
+	}
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false, "Read Only Category"),
+			},
+			// Refreshing and planning work as usual
+			{
+				Config:   config(true, "Read Only Category"),
+				PlanOnly: true,
+			},
+			{
+				Config:      config(true, "Renamed Category"),
+				ExpectError: regexp.MustCompile(`Unable to update jamfpro_category: the provider is configured with\s+read_only`),
+			},
+			{
+				Config: config(false, "Read Only Category"),
+				Check: resource.TestCheckResourceAttr(
+					"jamfpro_category.test", "name", "Read Only Category"),
+			},
+		},
+	})
+}
@@ -0,0 +1,261 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"net/http"
+)
+
+var _ resource.Resource = &ApiIntegrationResource{}
+var _ resource.ResourceWithImportState = &ApiIntegrationResource{}
+
+func NewApiIntegrationResource() resource.Resource {
+	return &ApiIntegrationResource{}
+}
+
+type ApiIntegrationResource struct {
+	api      *apiClient
+	retry    retryPolicy
+	readOnly bool
+}
+
+func (a *ApiIntegrationResource) Configure(ctx context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	data, ok := request.ProviderData.(*providerData)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Resource Configure type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	a.retry = data.retry
+	a.api = data.api
+	a.readOnly = data.readOnly
+}
+
+func (a *ApiIntegrationResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_api_integration"
+}
+
+func (a *ApiIntegrationResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Represents an API integration in Jamf Pro, an API client authenticating with client credentials.",
+		MarkdownDescription: "This resource (`jamfpro_api_integration`) manages API integrations in Jamf Pro, the API " +
+			"clients authenticating with client credentials. Generate their credentials with " +
+			"`jamfpro_api_integration_client_credentials`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "ID of the API integration",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the API integration",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the API integration can authenticate. Defaults to true.",
+			},
+			"authorization_scopes": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "Names of the API roles granted to the API integration.",
+				MarkdownDescription: "Names of the API roles granted to the API integration, such as the `name` of " +
+					"`jamfpro_api_role`.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"access_token_lifetime_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(1800),
+				Description: "Lifetime of the access tokens issued to the API integration, in seconds. Defaults to 1800.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"client_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Client ID of the API integration",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (a *ApiIntegrationResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	if a.readOnly {
+		response.Diagnostics.AddError(readOnlyError("create", "jamfpro_api_integration"))
+		return
+	}
+
+	var data apiintegration
+
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var created apiIntegration
+	err := a.api.sendJSON(ctx, http.MethodPost, apiIntegrationsPath, apiIntegrationRequestWithState(data), &created)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create API integration, got error: %s", err),
+		)
+		return
+	}
+
+	state, err := apiIntegrationForState(created)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create API integration, got error: %s", err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "created an API integration")
+
+	response.Diagnostics.Append(response.State.Set(ctx, state)...)
+}
+
+func (a *ApiIntegrationResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var data apiintegration
+
+	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	integration, found, err := readAPIObject[apiIntegration](ctx, a.retry, a.api, apiIntegrationPath(data.Id.ValueInt64()))
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Client error",
+			fmt.Sprintf("Unable to read API integration with ID %d, got error: %s", data.Id.ValueInt64(), err),
+		)
+		return
+	}
+
+	if !found {
+		tflog.Warn(ctx, "API integration no longer exists in Jamf Pro, removing it from state", map[string]interface{}{
+			"id": data.Id.ValueInt64(),
+		})
+		response.State.RemoveResource(ctx)
+		return
+	}
+
+	state, err := apiIntegrationForState(integration)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Client error",
+			fmt.Sprintf("Unable to read API integration with ID %d, got error: %s", data.Id.ValueInt64(), err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "Read an API integration")
+
+	response.Diagnostics.Append(response.State.Set(ctx, state)...)
+}
+
+func (a *ApiIntegrationResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	if a.readOnly {
+		response.Diagnostics.AddError(readOnlyError("update", "jamfpro_api_integration"))
+		return
+	}
+
+	var data apiintegration
+
+	// Read Terraform plan data into the model
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var updated apiIntegration
+	var state apiintegration
+	err := a.api.sendJSON(ctx, http.MethodPut, apiIntegrationPath(data.Id.ValueInt64()), apiIntegrationRequestWithState(data), &updated)
+	if err == nil {
+		state, err = apiIntegrationForState(updated)
+	}
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update API integration with ID %d, got error: %s", data.Id.ValueInt64(), err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "updated an API integration")
+
+	// Save updated data into Terraform state
+	response.Diagnostics.Append(response.State.Set(ctx, state)...)
+}
+
+func (a *ApiIntegrationResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	if a.readOnly {
+		response.Diagnostics.AddError(readOnlyError("delete", "jamfpro_api_integration"))
+		return
+	}
+
+	var data apiintegration
+
+	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	err := a.api.sendJSON(ctx, http.MethodDelete, apiIntegrationPath(data.Id.ValueInt64()), nil, nil)
+	if err != nil && !isNotFoundError(err) {
+		response.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete API integration with ID %d, got error: %s", data.Id.ValueInt64(), err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted an API integration")
+}
+
+func (a *ApiIntegrationResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	resourceImportStatePassthroughJamfProID(ctx, "api_integration", request, response, map[string]importIDLookup{
+		"name": func(ctx context.Context, name string) (int, diag.Diagnostics) {
+			return lookUpIDByName(ctx, a.api, apiIntegrationsPath, "displayName", "API integration", name)
+		},
+	})
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccApiIntegrationResource(t *testing.T) {
+	name := acctest.RandString(12)
+	newName := acctest.RandString(12)
+	resourceName := "jamfpro_api_integration.test"
+	credentialsName := "jamfpro_api_integration_client_credentials.test"
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read
+			{
+				Config: testAccApiIntegrationResourceConfig(name, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "display_name", name),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "access_token_lifetime_seconds", "1800"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "authorization_scopes.*", "jamfpro_api_role.test_role", "name"),
+					resource.TestCheckResourceAttrSet(resourceName, "client_id"),
+					resource.TestCheckResourceAttrPair(credentialsName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(credentialsName, "client_id", resourceName, "client_id"),
+					resource.TestMatchResourceAttr(credentialsName, "client_secret", regexp.MustCompile(`^.{16,}$`)),
+				),
+			},
+			// ImportState
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// ImportState by name
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "name:" + name,
+				ImportStateVerify: true,
+			},
+			// Update and Read, keeping the client credentials
+			{
+				Config: testAccApiIntegrationResourceConfig(newName, `
+  enabled                       = false
+  access_token_lifetime_seconds = 300`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "display_name", newName),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "access_token_lifetime_seconds", "300"),
+					resource.TestCheckResourceAttrPair(credentialsName, "client_id", resourceName, "client_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccApiIntegrationClientCredentialsResource_rotation(t *testing.T) {
+	name := acctest.RandString(12)
+	credentialsName := "jamfpro_api_integration_client_credentials.test"
+	var clientSecret string
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApiIntegrationClientCredentialsRotationConfig(name, "2026-10-01"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(credentialsName, "rotation_triggers.rotated_on", "2026-10-01"),
+					func(s *terraform.State) error {
+						clientSecret = s.RootModule().Resources[credentialsName].Primary.Attributes["client_secret"]
+						return nil
+					},
+				),
+			},
+			// Changing the triggers generates a new client secret
+			{
+				Config: testAccApiIntegrationClientCredentialsRotationConfig(name, "2026-11-01"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(credentialsName, "rotation_triggers.rotated_on", "2026-11-01"),
+					resource.TestMatchResourceAttr(credentialsName, "client_secret", regexp.MustCompile(`^.{16,}$`)),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources[credentialsName].Primary.Attributes["client_secret"] == clientSecret {
+							return fmt.Errorf("client_secret was not rotated")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccApiIntegrationResource_invalid(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccApiIntegrationResourceInvalidConfig("[]", 1800),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`set must contain at least 1 elements`),
+			},
+			{
+				Config:      testAccApiIntegrationResourceInvalidConfig(`["Reader"]`, 0),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`value must be at least 1`),
+			},
+		},
+	})
+}
+
+func testAccApiIntegrationResourceConfig(name string, settings string) string {
+	return testAccApiRoleResourceConfig(name, []string{"Read Computers"}) + fmt.Sprintf(`
+resource "jamfpro_api_integration" "test" {
+  display_name         = %q
+  authorization_scopes = [jamfpro_api_role.test_role.name]%s
+}
+
+resource "jamfpro_api_integration_client_credentials" "test" {
+  api_integration_id = jamfpro_api_integration.test.id
+}
+`, name, settings)
+}
+
+func testAccApiIntegrationClientCredentialsRotationConfig(name string, rotatedOn string) string {
+	return testAccApiRoleResourceConfig(name, []string{"Read Computers"}) + fmt.Sprintf(`
+resource "jamfpro_api_integration" "test" {
+  display_name         = %q
+  authorization_scopes = [jamfpro_api_role.test_role.name]
+}
+
+resource "jamfpro_api_integration_client_credentials" "test" {
+  api_integration_id = jamfpro_api_integration.test.id
+
+  rotation_triggers = {
+    rotated_on = %q
+  }
+}
+`, name, rotatedOn)
+}
+
+func testAccApiIntegrationResourceInvalidConfig(scopes string, lifetime int) string {
+	return fmt.Sprintf(`
+resource "jamfpro_api_integration" "test" {
+  display_name                  = "Invalid"
+  authorization_scopes          = %s
+  access_token_lifetime_seconds = %d
+}`, scopes, lifetime)
+}
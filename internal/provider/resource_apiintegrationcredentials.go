@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"net/http"
+	"strconv"
+)
+
+var _ resource.Resource = &ApiIntegrationCredentialsResource{}
+
+func NewApiIntegrationCredentialsResource() resource.Resource {
+	return &ApiIntegrationCredentialsResource{}
+}
+
+// ApiIntegrationCredentialsResource generates client credentials for an API
+// integration. Jamf Pro only returns the client secret when it is generated,
+// so it is kept in state.
+type ApiIntegrationCredentialsResource struct {
+	api      *apiClient
+	retry    retryPolicy
+	readOnly bool
+}
+
+type apiintegrationcredentials struct {
+	Id               types.String `tfsdk:"id"`
+	ApiIntegrationId types.Int64  `tfsdk:"api_integration_id"`
+	ClientId         types.String `tfsdk:"client_id"`
+	ClientSecret     types.String `tfsdk:"client_secret"`
+	RotationTriggers types.Map    `tfsdk:"rotation_triggers"`
+}
+
+func (a *ApiIntegrationCredentialsResource) Configure(ctx context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	data, ok := request.ProviderData.(*providerData)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Resource Configure type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	a.retry = data.retry
+	a.api = data.api
+	a.readOnly = data.readOnly
+}
+
+func (a *ApiIntegrationCredentialsResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_api_integration_client_credentials"
+}
+
+func (a *ApiIntegrationCredentialsResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Represents client credentials generated for an API integration in Jamf Pro.",
+		MarkdownDescription: "This resource (`jamfpro_api_integration_client_credentials`) generates client " +
+			"credentials for an API integration in Jamf Pro. Generating credentials replaces those generated " +
+			"before, so manage at most one per API integration. Changing `rotation_triggers` generates new " +
+			"credentials, rotating the client secret. " +
+			"Destroying it does not revoke the credentials: the client secret stays valid until new credentials " +
+			"are generated or the API integration is disabled or deleted.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the API integration.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"api_integration_id": schema.Int64Attribute{
+				Required:    true,
+				Description: "ID of the API integration to generate client credentials for.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"rotation_triggers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Arbitrary values that generate new client credentials when changed, such as the date " +
+					"of the last rotation.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"client_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Client ID of the API integration.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"client_secret": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Client secret generated for the API integration.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (a *ApiIntegrationCredentialsResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	if a.readOnly {
+		response.Diagnostics.AddError(readOnlyError("create", "jamfpro_api_integration_client_credentials"))
+		return
+	}
+
+	var data apiintegrationcredentials
+
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var credentials apiIntegrationCredentials
+	path := apiIntegrationPath(data.ApiIntegrationId.ValueInt64()) + "/client-credentials"
+	if err := a.api.sendJSON(ctx, http.MethodPost, path, nil, &credentials); err != nil {
+		response.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to generate client credentials for API integration with ID %d, got error: %s",
+				data.ApiIntegrationId.ValueInt64(), err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "generated client credentials for an API integration")
+
+	response.Diagnostics.Append(response.State.Set(ctx, apiintegrationcredentials{
+		Id:               types.StringValue(strconv.FormatInt(data.ApiIntegrationId.ValueInt64(), 10)),
+		ApiIntegrationId: data.ApiIntegrationId,
+		ClientId:         types.StringValue(credentials.ClientId),
+		ClientSecret:     types.StringValue(credentials.ClientSecret),
+		RotationTriggers: data.RotationTriggers,
+	})...)
+}
+
+// Read removes the credentials from state once their API integration is
+// deleted, or its client ID changes, as the client secret is no longer valid.
+func (a *ApiIntegrationCredentialsResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var data apiintegrationcredentials
+
+	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	integration, found, err := readAPIObject[apiIntegration](ctx, a.retry, a.api, apiIntegrationPath(data.ApiIntegrationId.ValueInt64()))
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Client error",
+			fmt.Sprintf("Unable to read API integration with ID %d, got error: %s", data.ApiIntegrationId.ValueInt64(), err),
+		)
+		return
+	}
+
+	if !found || (integration.ClientId != "" && integration.ClientId != data.ClientId.ValueString()) {
+		tflog.Warn(ctx, "Client credentials are no longer those of their API integration in Jamf Pro, removing them from state", map[string]interface{}{
+			"api_integration_id": data.ApiIntegrationId.ValueInt64(),
+		})
+		response.State.RemoveResource(ctx)
+		return
+	}
+
+	tflog.Trace(ctx, "Read client credentials of an API integration")
+
+	response.Diagnostics.Append(response.State.Set(ctx, data)...)
+}
+
+// Update is not called, as every change to the configuration replaces the credentials.
+func (a *ApiIntegrationCredentialsResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var data apiintegrationcredentials
+
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+	response.Diagnostics.Append(response.State.Set(ctx, data)...)
+}
+
+// Delete only removes the credentials from state. Jamf Pro cannot revoke
+// client credentials, other than by generating new ones.
+func (a *ApiIntegrationCredentialsResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	tflog.Trace(ctx, "removed client credentials of an API integration")
+}
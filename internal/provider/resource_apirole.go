@@ -3,6 +3,8 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
@@ -14,13 +16,17 @@ import (
 
 var _ resource.Resource = &ApiRoleResource{}
 var _ resource.ResourceWithImportState = &ApiRoleResource{}
+var _ resource.ResourceWithValidateConfig = &ApiRoleResource{}
 
 func NewApiRoleResource() resource.Resource {
 	return &ApiRoleResource{}
 }
 
 type ApiRoleResource struct {
-	client *jamfpro.Client
+	client   *jamfpro.Client
+	api      *apiClient
+	retry    retryPolicy
+	readOnly bool
 }
 
 func (a *ApiRoleResource) Configure(ctx context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
@@ -29,18 +35,21 @@ func (a *ApiRoleResource) Configure(ctx context.Context, request resource.Config
 		return
 	}
 
-	client, ok := request.ProviderData.(*jamfpro.Client)
+	data, ok := request.ProviderData.(*providerData)
 
 	if !ok {
 		response.Diagnostics.AddError(
 			"Unexpected Resource Configure type",
-			fmt.Sprintf("Expected *jamfpro.Client, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
 		)
 
 		return
 	}
 
-	a.client = client
+	a.client = data.client
+	a.retry = data.retry
+	a.api = data.api
+	a.readOnly = data.readOnly
 }
 
 func (a *ApiRoleResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
@@ -65,7 +74,10 @@ func (a *ApiRoleResource) Schema(ctx context.Context, request resource.SchemaReq
 				Description: "Name of the API role",
 			},
 			"privileges": schema.SetAttribute{
-				Description: "The privileges granted to the API role",
+				Description: "The privileges granted to the API role. They are validated against the privileges " +
+					"listed by the jamfpro_api_role_privileges data source.",
+				MarkdownDescription: "The privileges granted to the API role. They are validated against the " +
+					"privileges listed by the `jamfpro_api_role_privileges` data source.",
 				ElementType: types.StringType,
 				Required:    true,
 			},
@@ -74,6 +86,11 @@ func (a *ApiRoleResource) Schema(ctx context.Context, request resource.SchemaReq
 }
 
 func (a *ApiRoleResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	if a.readOnly {
+		response.Diagnostics.AddError(readOnlyError("create", "jamfpro_api_role"))
+		return
+	}
+
 	var data apirole
 
 	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
@@ -115,7 +132,7 @@ func (a *ApiRoleResource) Read(ctx context.Context, request resource.ReadRequest
 		return
 	}
 
-	apirole, _, err := a.client.ApiRoles.GetByID(ctx, int(data.Id.ValueInt64()))
+	apirole, found, err := readJamfProObject(ctx, a.retry, a.client.ApiRoles.GetByID, int(data.Id.ValueInt64()))
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Client error",
@@ -124,12 +141,25 @@ func (a *ApiRoleResource) Read(ctx context.Context, request resource.ReadRequest
 		return
 	}
 
+	if !found {
+		tflog.Warn(ctx, "API role no longer exists in Jamf Pro, removing it from state", map[string]interface{}{
+			"id": data.Id.ValueInt64(),
+		})
+		response.State.RemoveResource(ctx)
+		return
+	}
+
 	tflog.Trace(ctx, "Read an API role")
 
 	response.Diagnostics.Append(response.State.Set(ctx, apiRoleForState(apirole))...)
 }
 
 func (a *ApiRoleResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	if a.readOnly {
+		response.Diagnostics.AddError(readOnlyError("update", "jamfpro_api_role"))
+		return
+	}
+
 	var data apirole
 
 	// Read Terraform plan data into the model
@@ -164,6 +194,11 @@ func (a *ApiRoleResource) Update(ctx context.Context, request resource.UpdateReq
 }
 
 func (a *ApiRoleResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	if a.readOnly {
+		response.Diagnostics.AddError(readOnlyError("delete", "jamfpro_api_role"))
+		return
+	}
+
 	var data apirole
 
 	diags := request.State.Get(ctx, &data)
@@ -173,8 +208,8 @@ func (a *ApiRoleResource) Delete(ctx context.Context, request resource.DeleteReq
 		return
 	}
 
-	_, err := a.client.ApiRoles.Delete(ctx, int(data.Id.ValueInt64()))
-	if err != nil {
+	resp, err := a.client.ApiRoles.Delete(ctx, int(data.Id.ValueInt64()))
+	if err != nil && !isNotFound(resp) {
 		response.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to delete API role with ID %d, got error: %s", data.Id.ValueInt64(), err),
@@ -186,5 +221,41 @@ func (a *ApiRoleResource) Delete(ctx context.Context, request resource.DeleteReq
 }
 
 func (a *ApiRoleResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
-	resourceImportStatePassthroughJamfProID(ctx, "api_role", request, response)
+	resourceImportStatePassthroughJamfProID(ctx, "api_role", request, response, map[string]importIDLookup{
+		"name": func(ctx context.Context, name string) (int, diag.Diagnostics) {
+			return lookUpIDByName(ctx, a.api, "/api/v1/api-roles", "displayName", "API role", name)
+		},
+	})
+}
+
+// ValidateConfig checks the privileges against those API roles can grant on
+// the instance, once the provider is configured and the privileges are known.
+func (a *ApiRoleResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var privileges types.Set
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("privileges"), &privileges)...)
+	if response.Diagnostics.HasError() || privileges.IsNull() || privileges.IsUnknown() || a.api == nil {
+		return
+	}
+
+	names := make([]string, 0, len(privileges.Elements()))
+	for _, element := range privileges.Elements() {
+		if privilege, ok := element.(types.String); ok && !privilege.IsNull() && !privilege.IsUnknown() {
+			names = append(names, privilege.ValueString())
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	known, err := a.api.apiRolePrivileges(ctx)
+	if err != nil {
+		response.Diagnostics.AddAttributeWarning(
+			path.Root("privileges"),
+			"Unable to validate API role privileges",
+			fmt.Sprintf("Unable to list the privileges API roles can grant, got error: %s. The privileges are "+
+				"validated by Jamf Pro when the API role is applied.", err),
+		)
+		return
+	}
+	response.Diagnostics.Append(validateApiRolePrivileges(names, known, path.Root("privileges"))...)
 }
@@ -3,6 +3,7 @@ package provider
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strconv"
 	"testing"
 
@@ -17,7 +18,7 @@ func TestAccApiRoleResource(t *testing.T) {
 	NewPrivileges := []string{"Read Teacher App Settings", "Read SMTP Server", "Read PKI", "Read iBeacon"}
 	resourceName := "jamfpro_api_role.test_role"
 
-	resource.Test(t, resource.TestCase{
+	testAccRun(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
@@ -43,6 +44,13 @@ func TestAccApiRoleResource(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// ImportState by name
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "name:" + Name,
+				ImportStateVerify: true,
+			},
 			// Update and Read
 			{
 				Config: testAccApiRoleResourceConfig(NewName, NewPrivileges),
@@ -74,3 +82,17 @@ resource "jamfpro_api_role" "test_role" {
 }
 `, name, string(b))
 }
+
+func TestAccApiRoleResource_invalidPrivileges(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccApiRoleResourceConfig(acctest.RandString(12), []string{"Read Buildings", "Read Computer"}),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`"Read Computer" is not a privilege API roles can grant in Jamf Pro.\s+Did you mean\s+"Read Computers"\?`),
+			},
+		},
+	})
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
@@ -21,7 +22,10 @@ func NewBuildingResource() resource.Resource {
 }
 
 type BuildingResource struct {
-	client *jamfpro.Client
+	client   *jamfpro.Client
+	api      *apiClient
+	retry    retryPolicy
+	readOnly bool
 }
 
 func (b *BuildingResource) Configure(ctx context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
@@ -30,18 +34,21 @@ func (b *BuildingResource) Configure(ctx context.Context, request resource.Confi
 		return
 	}
 
-	client, ok := request.ProviderData.(*jamfpro.Client)
+	data, ok := request.ProviderData.(*providerData)
 
 	if !ok {
 		response.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *jamfpro.Client, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
 		)
 
 		return
 	}
 
-	b.client = client
+	b.client = data.client
+	b.retry = data.retry
+	b.api = data.api
+	b.readOnly = data.readOnly
 }
 
 func (b *BuildingResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
@@ -94,6 +101,11 @@ func (b *BuildingResource) Schema(ctx context.Context, request resource.SchemaRe
 }
 
 func (b *BuildingResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	if b.readOnly {
+		response.Diagnostics.AddError(readOnlyError("create", "jamfpro_building"))
+		return
+	}
+
 	var data building
 
 	// Read Terraform plan data into the model
@@ -137,7 +149,7 @@ func (b *BuildingResource) Read(ctx context.Context, request resource.ReadReques
 		return
 	}
 
-	building, _, err := b.client.Buildings.GetByID(ctx, int(data.Id.ValueInt64()))
+	building, found, err := readJamfProObject(ctx, b.retry, b.client.Buildings.GetByID, int(data.Id.ValueInt64()))
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Client error",
@@ -146,6 +158,14 @@ func (b *BuildingResource) Read(ctx context.Context, request resource.ReadReques
 		return
 	}
 
+	if !found {
+		tflog.Warn(ctx, "building no longer exists in Jamf Pro, removing it from state", map[string]interface{}{
+			"id": data.Id.ValueInt64(),
+		})
+		response.State.RemoveResource(ctx)
+		return
+	}
+
 	tflog.Trace(ctx, "read a building")
 
 	// Save updated data into Terraform state
@@ -153,6 +173,11 @@ func (b *BuildingResource) Read(ctx context.Context, request resource.ReadReques
 }
 
 func (b *BuildingResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	if b.readOnly {
+		response.Diagnostics.AddError(readOnlyError("update", "jamfpro_building"))
+		return
+	}
+
 	var data building
 
 	// Read Terraform plan data into the model
@@ -187,6 +212,11 @@ func (b *BuildingResource) Update(ctx context.Context, request resource.UpdateRe
 }
 
 func (b *BuildingResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	if b.readOnly {
+		response.Diagnostics.AddError(readOnlyError("delete", "jamfpro_building"))
+		return
+	}
+
 	var data building
 
 	diags := request.State.Get(ctx, &data)
@@ -196,8 +226,8 @@ func (b *BuildingResource) Delete(ctx context.Context, request resource.DeleteRe
 		return
 	}
 
-	_, err := b.client.Buildings.Delete(ctx, int(data.Id.ValueInt64()))
-	if err != nil {
+	resp, err := b.client.Buildings.Delete(ctx, int(data.Id.ValueInt64()))
+	if err != nil && !isNotFound(resp) {
 		response.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to delete %s with ID %d, got error: %s", resourceName, data.Id.ValueInt64(), err),
@@ -209,5 +239,9 @@ func (b *BuildingResource) Delete(ctx context.Context, request resource.DeleteRe
 }
 
 func (b *BuildingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resourceImportStatePassthroughJamfProID(ctx, resourceName, req, resp)
+	resourceImportStatePassthroughJamfProID(ctx, resourceName, req, resp, map[string]importIDLookup{
+		"name": func(ctx context.Context, name string) (int, diag.Diagnostics) {
+			return lookUpIDByName(ctx, b.api, "/api/v1/buildings", "name", "building", name)
+		},
+	})
 }
@@ -20,7 +20,7 @@ func TestAccBuildingResource(t *testing.T) {
 	newCountry := acctest.RandString(12)
 	resourceName := "jamfpro_building.test"
 
-	resource.Test(t, resource.TestCase{
+	testAccRun(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
@@ -50,6 +50,13 @@ func TestAccBuildingResource(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// ImportState by name
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "name:" + Name,
+				ImportStateVerify: true,
+			},
 			// Update and Read
 			{
 				Config: testAccBuildingResourceConfig(Name, StreetAddress1, StreetAddress2, newCity, StateProvince, ZipPostalCode, newCountry),
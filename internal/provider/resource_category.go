@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
@@ -21,7 +22,10 @@ func NewCategoryResource() resource.Resource {
 }
 
 type CategoryResource struct {
-	client *jamfpro.Client
+	client   *jamfpro.Client
+	api      *apiClient
+	retry    retryPolicy
+	readOnly bool
 }
 
 func (c *CategoryResource) Configure(ctx context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
@@ -30,18 +34,21 @@ func (c *CategoryResource) Configure(ctx context.Context, request resource.Confi
 		return
 	}
 
-	client, ok := request.ProviderData.(*jamfpro.Client)
+	data, ok := request.ProviderData.(*providerData)
 
 	if !ok {
 		response.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *jamfpro.Client, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
 		)
 
 		return
 	}
 
-	c.client = client
+	c.client = data.client
+	c.retry = data.retry
+	c.api = data.api
+	c.readOnly = data.readOnly
 }
 
 func (c *CategoryResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
@@ -77,6 +84,11 @@ func (c *CategoryResource) Schema(ctx context.Context, request resource.SchemaRe
 }
 
 func (c *CategoryResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	if c.readOnly {
+		response.Diagnostics.AddError(readOnlyError("create", "jamfpro_category"))
+		return
+	}
+
 	var data category
 
 	// Read Terraform plan data into the model
@@ -115,7 +127,7 @@ func (c *CategoryResource) Read(ctx context.Context, request resource.ReadReques
 		return
 	}
 
-	category, _, err := c.client.Categories.GetByID(ctx, int(data.Id.ValueInt64()))
+	category, found, err := readJamfProObject(ctx, c.retry, c.client.Categories.GetByID, int(data.Id.ValueInt64()))
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Client error",
@@ -124,6 +136,14 @@ func (c *CategoryResource) Read(ctx context.Context, request resource.ReadReques
 		return
 	}
 
+	if !found {
+		tflog.Warn(ctx, "category no longer exists in Jamf Pro, removing it from state", map[string]interface{}{
+			"id": data.Id.ValueInt64(),
+		})
+		response.State.RemoveResource(ctx)
+		return
+	}
+
 	tflog.Trace(ctx, "read a Category")
 
 	// Save updated data into Terraform state
@@ -131,6 +151,11 @@ func (c *CategoryResource) Read(ctx context.Context, request resource.ReadReques
 }
 
 func (c *CategoryResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	if c.readOnly {
+		response.Diagnostics.AddError(readOnlyError("update", "jamfpro_category"))
+		return
+	}
+
 	var data category
 
 	// Read Terraform plan data into the model
@@ -160,6 +185,11 @@ func (c *CategoryResource) Update(ctx context.Context, request resource.UpdateRe
 }
 
 func (c *CategoryResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	if c.readOnly {
+		response.Diagnostics.AddError(readOnlyError("delete", "jamfpro_category"))
+		return
+	}
+
 	var data category
 
 	diags := request.State.Get(ctx, &data)
@@ -169,8 +199,8 @@ func (c *CategoryResource) Delete(ctx context.Context, request resource.DeleteRe
 		return
 	}
 
-	_, err := c.client.Categories.Delete(ctx, int(data.Id.ValueInt64()))
-	if err != nil {
+	resp, err := c.client.Categories.Delete(ctx, int(data.Id.ValueInt64()))
+	if err != nil && !isNotFound(resp) {
 		response.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to delete category with ID %d, got error: %s", data.Id.ValueInt64(), err),
@@ -182,5 +212,9 @@ func (c *CategoryResource) Delete(ctx context.Context, request resource.DeleteRe
 }
 
 func (c *CategoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resourceImportStatePassthroughJamfProID(ctx, "category", req, resp)
+	resourceImportStatePassthroughJamfProID(ctx, "category", req, resp, map[string]importIDLookup{
+		"name": func(ctx context.Context, name string) (int, diag.Diagnostics) {
+			return lookUpIDByName(ctx, c.api, "/api/v1/categories", "name", "category", name)
+		},
+	})
 }
@@ -1,12 +1,15 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"strconv"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jc0b/go-jamfpro-api/jamfpro"
 )
 
 func TestAccCategoryResource(t *testing.T) {
@@ -16,7 +19,7 @@ func TestAccCategoryResource(t *testing.T) {
 	newPriority := acctest.RandIntRange(1, 20)
 	resourceName := "jamfpro_category.test"
 
-	resource.Test(t, resource.TestCase{
+	testAccRun(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
@@ -36,6 +39,27 @@ func TestAccCategoryResource(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// ImportState by name
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "name:" + Name,
+				ImportStateVerify: true,
+			},
+			// ImportState by an unknown name
+			{
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "name:No Such Category",
+				ExpectError:   regexp.MustCompile(`No category named 'No Such Category' exists`),
+			},
+			// ImportState by an unsupported import ID
+			{
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "serial:" + Name,
+				ExpectError:   regexp.MustCompile(`ID must be an integer, or one of name:<value>`),
+			},
 			// Update and Read
 			{
 				Config: testAccCategoryResourceConfig(newName, newPriority),
@@ -50,6 +74,26 @@ func TestAccCategoryResource(t *testing.T) {
 	})
 }
 
+func TestAccCategoryResource_disappears(t *testing.T) {
+	resourceName := "jamfpro_category.test"
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Deleted in Jamf Pro, so recreated on the next apply
+			{
+				Config: testAccCategoryResourceConfig(acctest.RandString(12), 5),
+				Check: testAccCheckResourceDisappears(t, resourceName, func(ctx context.Context, client *jamfpro.Client, id int) error {
+					_, err := client.Categories.Delete(ctx, id)
+					return err
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func testAccCategoryResourceConfig(name string, priority int) string {
 	return fmt.Sprintf(`
 resource "jamfpro_category" "test" {
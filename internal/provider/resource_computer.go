@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
@@ -19,7 +20,10 @@ func NewComputerResource() resource.Resource {
 }
 
 type ComputerResource struct {
-	client *jamfpro.Client
+	client   *jamfpro.Client
+	api      *apiClient
+	retry    retryPolicy
+	readOnly bool
 }
 
 func (c ComputerResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
@@ -63,20 +67,28 @@ func (c *ComputerResource) Configure(ctx context.Context, request resource.Confi
 		return
 	}
 
-	client, ok := request.ProviderData.(*jamfpro.Client)
+	data, ok := request.ProviderData.(*providerData)
 
 	if !ok {
 		response.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *jamfpro.Client, got: #{request.ProviderData}. Please report this issue to the provider developers."),
+			fmt.Sprintf("Expected *providerData, got: #{request.ProviderData}. Please report this issue to the provider developers."),
 		)
 
 		return
 	}
 
-	c.client = client
+	c.client = data.client
+	c.retry = data.retry
+	c.api = data.api
+	c.readOnly = data.readOnly
 }
 
 func (c *ComputerResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	if c.readOnly {
+		response.Diagnostics.AddError(readOnlyError("create", "jamfpro_computer"))
+		return
+	}
+
 	var data computer
 
 	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
@@ -115,7 +127,7 @@ func (c *ComputerResource) Read(ctx context.Context, request resource.ReadReques
 		return
 	}
 
-	computer, _, err := c.client.Computers.GetByID(ctx, int(data.Id.ValueInt64()))
+	computer, found, err := readJamfProObject(ctx, c.retry, c.client.Computers.GetByID, int(data.Id.ValueInt64()))
 
 	if err != nil {
 		response.Diagnostics.AddError(
@@ -125,6 +137,14 @@ func (c *ComputerResource) Read(ctx context.Context, request resource.ReadReques
 		return
 	}
 
+	if !found {
+		tflog.Warn(ctx, "computer no longer exists in Jamf Pro, removing it from state", map[string]interface{}{
+			"id": data.Id.ValueInt64(),
+		})
+		response.State.RemoveResource(ctx)
+		return
+	}
+
 	tflog.Trace(ctx, "read a computer")
 
 	// Save updated data into Terraform state
@@ -132,6 +152,11 @@ func (c *ComputerResource) Read(ctx context.Context, request resource.ReadReques
 }
 
 func (c *ComputerResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	if c.readOnly {
+		response.Diagnostics.AddError(readOnlyError("update", "jamfpro_computer"))
+		return
+	}
+
 	var data computer
 
 	// Read Terraform plan data into the model
@@ -166,6 +191,11 @@ func (c *ComputerResource) Update(ctx context.Context, request resource.UpdateRe
 }
 
 func (c *ComputerResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	if c.readOnly {
+		response.Diagnostics.AddError(readOnlyError("delete", "jamfpro_computer"))
+		return
+	}
+
 	var data computer
 
 	diags := request.State.Get(ctx, &data)
@@ -175,8 +205,8 @@ func (c *ComputerResource) Delete(ctx context.Context, request resource.DeleteRe
 		return
 	}
 
-	_, err := c.client.Computers.Delete(ctx, int(data.Id.ValueInt64()))
-	if err != nil {
+	resp, err := c.client.Computers.Delete(ctx, int(data.Id.ValueInt64()))
+	if err != nil && !isNotFound(resp) {
 		response.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to delete computer with ID %d, got error: %s", data.Id.ValueInt64(), err.Error()),
@@ -188,7 +218,30 @@ func (c *ComputerResource) Delete(ctx context.Context, request resource.DeleteRe
 }
 
 func (c *ComputerResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
-	resourceImportStatePassthroughJamfProID(ctx, "computer", request, response)
+	resourceImportStatePassthroughJamfProID(ctx, "computer", request, response, map[string]importIDLookup{
+		"name": func(ctx context.Context, name string) (int, diag.Diagnostics) {
+			return lookUpIDByName(ctx, c.api, "/api/v1/computers-inventory", "general.name", "computer", name)
+		},
+		"serial": func(ctx context.Context, serialNumber string) (int, diag.Diagnostics) {
+			var diags diag.Diagnostics
+			computer, resp, err := c.client.Computers.GetBySerialNumber(ctx, serialNumber)
+			if isNotFound(resp) {
+				diags.AddError(
+					"No matching computer",
+					fmt.Sprintf("No computer with serial number '%s' exists in Jamf Pro.", serialNumber),
+				)
+				return 0, diags
+			}
+			if err != nil {
+				diags.AddError(
+					"Client Error",
+					fmt.Sprintf("Unable to look up computer with serial number '%s', got error: %s", serialNumber, err),
+				)
+				return 0, diags
+			}
+			return computer.Id, diags
+		},
+	})
 }
 
 func (c *ComputerResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
@@ -15,7 +15,7 @@ func TestAccComputerResource(t *testing.T) {
 	newSerialNumber := randomSerialNumber()
 	resourceName := "jamfpro_computer.test"
 
-	resource.Test(t, resource.TestCase{
+	testAccRun(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
@@ -35,6 +35,20 @@ func TestAccComputerResource(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// ImportState by name
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "name:" + Name,
+				ImportStateVerify: true,
+			},
+			// ImportState by serial number
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "serial:" + serialNumber,
+				ImportStateVerify: true,
+			},
 			// Update and Read
 			{
 				Config: testAccComputerResourceConfig(newName, newSerialNumber),
@@ -2,25 +2,33 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jc0b/go-jamfpro-api/jamfpro"
-	"time"
 )
 
 var _ resource.Resource = &ComputerGroupResource{}
 var _ resource.ResourceWithImportState = &ComputerGroupResource{}
+var _ resource.ResourceWithConfigValidators = &ComputerGroupResource{}
+var _ resource.ResourceWithMoveState = &ComputerGroupResource{}
 
 func NewComputerGroupResource() resource.Resource {
 	return &ComputerGroupResource{}
 }
 
 type ComputerGroupResource struct {
-	client *jamfpro.Client
+	client   *jamfpro.Client
+	retry    retryPolicy
+	readOnly bool
 }
 
 func (c ComputerGroupResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
@@ -28,11 +36,24 @@ func (c ComputerGroupResource) Metadata(ctx context.Context, request resource.Me
 
 }
 
+func (c ComputerGroupResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("computer_ids"),
+			path.MatchRoot("serial_numbers"),
+			path.MatchRoot("computers"),
+		),
+	}
+}
+
 func (c ComputerGroupResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
 	response.Schema = schema.Schema{
 		Description:         "Represents a Computer Group resource in Jamf Pro",
 		MarkdownDescription: "This resource (`jamfpro_computergroup`) manages Computer Groups in Jamf Pro",
 
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
 				Computed:    true,
@@ -45,10 +66,33 @@ func (c ComputerGroupResource) Schema(ctx context.Context, request resource.Sche
 				Required:    true,
 				Description: "Name of the Computer Group",
 			},
+			"computer_ids": schema.SetAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.Int64Type,
+				Description: "IDs of the computers that are members of the static group. Exactly one of computer_ids, " +
+					"serial_numbers and computers must be set.",
+				MarkdownDescription: "`ID`s of the computers that are members of the static group. Exactly one of " +
+					"`computer_ids`, `serial_numbers` and `computers` must be set.",
+			},
+			"serial_numbers": schema.SetAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Serial numbers of the computers that are members of the static group. Exactly one of " +
+					"computer_ids, serial_numbers and computers must be set.",
+				MarkdownDescription: "Serial numbers of the computers that are members of the static group. Exactly " +
+					"one of `computer_ids`, `serial_numbers` and `computers` must be set.",
+			},
 			"computers": schema.SetNestedAttribute{
-				Required:    true,
-				Computed:    false,
-				Description: "Represents computers that are members of a static group.",
+				Optional: true,
+				Computed: true,
+				Description: "Represents computers that are members of a static group. Computed from the members " +
+					"when computer_ids or serial_numbers is set instead, so that changes to the computers, such as " +
+					"renames, are not reported as drift.",
+				MarkdownDescription: "Represents computers that are members of a static group. Computed from the " +
+					"members when `computer_ids` or `serial_numbers` is set instead, so that changes to the " +
+					"computers, such as renames, are not reported as drift.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id": schema.Int64Attribute{
@@ -84,20 +128,27 @@ func (c *ComputerGroupResource) Configure(ctx context.Context, request resource.
 		return
 	}
 
-	client, ok := request.ProviderData.(*jamfpro.Client)
+	data, ok := request.ProviderData.(*providerData)
 
 	if !ok {
 		response.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *jamfpro.Client, got: #{request.ProviderData}. Please report this issue to the provider developers."),
+			fmt.Sprintf("Expected *providerData, got: #{request.ProviderData}. Please report this issue to the provider developers."),
 		)
 
 		return
 	}
 
-	c.client = client
+	c.client = data.client
+	c.retry = data.retry
+	c.readOnly = data.readOnly
 }
 
 func (c *ComputerGroupResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	if c.readOnly {
+		response.Diagnostics.AddError(readOnlyError("create", "jamfpro_computergroup"))
+		return
+	}
+
 	var data computergroup
 
 	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
@@ -106,6 +157,14 @@ func (c *ComputerGroupResource) Create(ctx context.Context, request resource.Cre
 		return
 	}
 
+	timeout, diags := timeoutFor(data.Timeouts, "create")
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	computerRequest := computerGroupRequestWithState(data)
 	computergroup, _, err := c.client.ComputerGroups.Create(ctx, computerRequest)
 	if err != nil {
@@ -115,15 +174,27 @@ func (c *ComputerGroupResource) Create(ctx context.Context, request resource.Cre
 		return
 	}
 
+	tflog.Trace(ctx, "Waiting for computergroup to propagate in Jamf")
+	observed, err := waitForComputerGroup(ctx, c.client, c.retry, computergroup)
+	if errors.Is(err, context.DeadlineExceeded) {
+		response.Diagnostics.AddError(computerGroupTimeoutError("computergroup", "creation", timeout, computergroup, observed))
+	} else if err != nil {
+		response.Diagnostics.AddWarning(
+			"Computer group propagation not checked",
+			fmt.Sprintf("Computergroup with ID %d was created, but checking that it propagated in Jamf Pro failed: %s", computergroup.Id, err))
+	}
+
 	tflog.Trace(ctx, "created a computergroup")
 
-	response.Diagnostics.Append(response.State.Set(ctx, computerGroupForState(computergroup))...)
+	state := computerGroupForState(computergroup)
+	state.Timeouts = data.Timeouts
+	response.Diagnostics.Append(response.State.Set(ctx, state)...)
 
 }
 
 func (c *ComputerGroupResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
 	var data computergroup
-	retryCount := 5
+
 	// Read Terraform prior state data into the model
 	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
 
@@ -131,14 +202,15 @@ func (c *ComputerGroupResource) Read(ctx context.Context, request resource.ReadR
 		return
 	}
 
-	computergroup, resp, err := c.client.ComputerGroups.GetByID(ctx, int(data.Id.ValueInt64()))
-	if resp.StatusCode == 404 {
-		for resp.StatusCode == 404 && retryCount > 0 {
-			time.Sleep(time.Duration(4) * time.Second)
-			computergroup, resp, err = c.client.ComputerGroups.GetByID(ctx, int(data.Id.ValueInt64()))
-			retryCount = retryCount - 1
-		}
+	timeout, diags := timeoutFor(data.Timeouts, "read")
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	computergroup, found, err := readJamfProObject(ctx, c.retry, c.client.ComputerGroups.GetByID, int(data.Id.ValueInt64()))
 
 	if err != nil {
 		response.Diagnostics.AddError(
@@ -148,15 +220,37 @@ func (c *ComputerGroupResource) Read(ctx context.Context, request resource.ReadR
 		return
 	}
 
+	if !found {
+		tflog.Warn(ctx, "computergroup no longer exists in Jamf Pro, removing it from state", map[string]interface{}{
+			"id": data.Id.ValueInt64(),
+		})
+		response.State.RemoveResource(ctx)
+		return
+	}
+
 	tflog.Trace(ctx, "read a computergroup")
 
 	// Save updated data into Terraform state
-	response.Diagnostics.Append(response.State.Set(ctx, computerGroupForState(computergroup))...)
+	state := computerGroupForState(computergroup)
+	if computergroup.IsSmart {
+		// A smart group has no static members. Leaving out those it evaluates to
+		// plans an update converting it to a static group, keeping its ID.
+		tflog.Info(ctx, "computergroup is a smart group, planning its conversion to a static group", map[string]interface{}{
+			"id": data.Id.ValueInt64(),
+		})
+		state = computerGroupForState(&jamfpro.ComputerGroup{Id: computergroup.Id, Name: computergroup.Name})
+	}
+	state.Timeouts = data.Timeouts
+	response.Diagnostics.Append(response.State.Set(ctx, state)...)
 }
 
 func (c *ComputerGroupResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	if c.readOnly {
+		response.Diagnostics.AddError(readOnlyError("update", "jamfpro_computergroup"))
+		return
+	}
+
 	var data computergroup
-	//retryCount := 5
 
 	// Read Terraform plan data into the model
 	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
@@ -165,16 +259,17 @@ func (c *ComputerGroupResource) Update(ctx context.Context, request resource.Upd
 		return
 	}
 
+	timeout, diags := timeoutFor(data.Timeouts, "update")
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	computerGroupUpdateRequest := computerGroupRequestWithState(data)
 
 	computerGroup, _, err := c.client.ComputerGroups.Update(ctx, int(data.Id.ValueInt64()), computerGroupUpdateRequest)
-	//if resp.StatusCode == 404 {
-	//	for resp.StatusCode == 404 && retryCount > 0 {
-	//		time.Sleep(time.Duration(2) * time.Second)
-	//		computerGroup, resp, err = c.client.ComputerGroups.Update(ctx, int(data.Id.ValueInt64()), computerGroupUpdateRequest)
-	//		retryCount = retryCount - 1
-	//	}
-	//}
 
 	if err != nil {
 		response.Diagnostics.AddError(
@@ -184,23 +279,30 @@ func (c *ComputerGroupResource) Update(ctx context.Context, request resource.Upd
 		return
 	}
 
-	//tflog.Trace(ctx, "Waiting for computergroup to propagate in Jamf")
-	//updatedComputerGroup, _, err := c.client.ComputerGroups.GetByID(ctx, computerGroup.Id)
-	//interval := 1
-	//for !AreGroupsEquivalent(computerGroup, updatedComputerGroup) {
-	//	time.Sleep(time.Duration(interval) * time.Second)
-	//	updatedComputerGroup, _, err = c.client.ComputerGroups.GetByID(ctx, computerGroup.Id)
-	//	interval = interval * 2
-	//}
-	//time.Sleep(time.Duration(interval) * time.Second)
+	tflog.Trace(ctx, "Waiting for computergroup to propagate in Jamf")
+	observed, err := waitForComputerGroup(ctx, c.client, c.retry, computerGroup)
+	if errors.Is(err, context.DeadlineExceeded) {
+		response.Diagnostics.AddError(computerGroupTimeoutError("computergroup", "update", timeout, computerGroup, observed))
+	} else if err != nil {
+		response.Diagnostics.AddWarning(
+			"Computer group propagation not checked",
+			fmt.Sprintf("Computergroup with ID %d was updated, but checking that it propagated in Jamf Pro failed: %s", computerGroup.Id, err))
+	}
 
 	tflog.Trace(ctx, "updated a computergroup")
 
 	// Save updated data into Terraform state
-	response.Diagnostics.Append(response.State.Set(ctx, computerGroupForState(computerGroup))...)
+	state := computerGroupForState(computerGroup)
+	state.Timeouts = data.Timeouts
+	response.Diagnostics.Append(response.State.Set(ctx, state)...)
 }
 
 func (c *ComputerGroupResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	if c.readOnly {
+		response.Diagnostics.AddError(readOnlyError("delete", "jamfpro_computergroup"))
+		return
+	}
+
 	var data computergroup
 
 	diags := request.State.Get(ctx, &data)
@@ -210,8 +312,16 @@ func (c *ComputerGroupResource) Delete(ctx context.Context, request resource.Del
 		return
 	}
 
-	_, err := c.client.ComputerGroups.Delete(ctx, int(data.Id.ValueInt64()))
-	if err != nil {
+	timeout, diags := timeoutFor(data.Timeouts, "delete")
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resp, err := c.client.ComputerGroups.Delete(ctx, int(data.Id.ValueInt64()))
+	if err != nil && !isNotFound(resp) {
 		response.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to delete computergroup with ID %d, got error: %s", data.Id.ValueInt64(), err.Error()),
@@ -223,7 +333,23 @@ func (c *ComputerGroupResource) Delete(ctx context.Context, request resource.Del
 }
 
 func (c *ComputerGroupResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
-	resourceImportStatePassthroughJamfProID(ctx, "computergroup", request, response)
+	resourceImportStatePassthroughJamfProID(ctx, "computergroup", request, response, map[string]importIDLookup{
+		"name": func(ctx context.Context, name string) (int, diag.Diagnostics) {
+			return lookUpComputerGroupIDByName(ctx, c.client, name)
+		},
+	})
+}
+
+// MoveState moves a jamfpro_smartcomputergroup to this resource with a moved
+// block. The next apply converts the group to a static group, keeping its ID.
+func (c *ComputerGroupResource) MoveState(ctx context.Context) []resource.StateMover {
+	smart := &SmartComputerGroupResource{}
+	var source resource.SchemaResponse
+	smart.Schema(ctx, resource.SchemaRequest{}, &source)
+	return []resource.StateMover{
+		computerGroupStateMover(source.Schema, "jamfpro_smartcomputergroup", moveSmartComputerGroupState),
+		computerGroupStateMover(*smart.UpgradeState(ctx)[0].PriorSchema, "jamfpro_smartcomputergroup", moveSmartComputerGroupState),
+	}
 }
 
 func (c *ComputerGroupResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
@@ -1,11 +1,20 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+	"strconv"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/jc0b/go-jamfpro-api/jamfpro"
 )
 
 func TestAccComputerGroupResource(t *testing.T) {
@@ -15,7 +24,7 @@ func TestAccComputerGroupResource(t *testing.T) {
 
 	resourceName := "jamfpro_computergroup.test"
 
-	resource.Test(t, resource.TestCase{
+	testAccRun(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
@@ -33,6 +42,13 @@ func TestAccComputerGroupResource(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// ImportState by name
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "name:" + Name,
+				ImportStateVerify: true,
+			},
 			// Update and Read
 			{
 				Config: testAccComputerGroupResourceConfig(serialNumber, newName),
@@ -45,6 +61,26 @@ func TestAccComputerGroupResource(t *testing.T) {
 	})
 }
 
+func TestAccComputerGroupResource_disappears(t *testing.T) {
+	resourceName := "jamfpro_computergroup.test"
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Deleted in Jamf Pro, so recreated on the next apply
+			{
+				Config: testAccComputerGroupResourceConfig(randomSerialNumber(), acctest.RandString(12)),
+				Check: testAccCheckResourceDisappears(t, resourceName, func(ctx context.Context, client *jamfpro.Client, id int) error {
+					_, err := client.ComputerGroups.Delete(ctx, id)
+					return err
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func testAccComputerGroupResourceConfig(serial_number string, name string) string {
 	return fmt.Sprintf(`
 resource "jamfpro_computer" "test_computer" {
@@ -62,3 +98,279 @@ resource "jamfpro_computergroup" "test" {
   computers = [jamfpro_computer.test_computer]
 }`, serial_number, name)
 }
+
+func TestAccComputerGroupResource_members(t *testing.T) {
+	name := acctest.RandString(12)
+	serialNumber := randomSerialNumber()
+	resourceName := "jamfpro_computergroup.test"
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputerGroupResourceMembersConfig(serialNumber, "Members Test Mac", name, "computer_ids = [jamfpro_computer.test.id]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "computer_ids.*", "jamfpro_computer.test", "id"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "serial_numbers.*", serialNumber),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "computers.*", map[string]string{
+						"serial_number": serialNumber,
+						"name":          "Members Test Mac",
+					}),
+				),
+			},
+			// Renaming a member is not drift of the group
+			{
+				Config: testAccComputerGroupResourceMembersConfig(serialNumber, "Renamed Test Mac", name, "computer_ids = [jamfpro_computer.test.id]"),
+				Check: resource.TestCheckTypeSetElemNestedAttrs(resourceName, "computers.*", map[string]string{
+					"name": "Renamed Test Mac",
+				}),
+			},
+			{
+				Config: testAccComputerGroupResourceMembersConfig(serialNumber, "Renamed Test Mac", name, "serial_numbers = [jamfpro_computer.test.serial_number]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "computer_ids.*", "jamfpro_computer.test", "id"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "serial_numbers.*", serialNumber),
+				),
+			},
+			{
+				Config: testAccComputerGroupResourceMembersConfig(serialNumber, "Renamed Test Mac", name,
+					"computer_ids = [jamfpro_computer.test.id]\n  serial_numbers = [jamfpro_computer.test.serial_number]"),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func testAccComputerGroupResourceMembersConfig(serialNumber string, computerName string, name string, members string) string {
+	return fmt.Sprintf(`
+resource "jamfpro_computer" "test" {
+  name          = %[2]q
+  serial_number = %[1]q
+}
+
+resource "jamfpro_computergroup" "test" {
+  name = %[3]q
+  %[4]s
+}`, serialNumber, computerName, name, members)
+}
+
+func TestAccComputerGroupResource_convertToSmart(t *testing.T) {
+	name := acctest.RandString(12)
+	serialNumber := randomSerialNumber()
+	var groupID int
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputerResourceConfig("Convert Test Mac", serialNumber),
+			},
+			// A static group created outside of Terraform is imported as a smart group
+			{
+				PreConfig: func() {
+					groupID = testAccCreateComputerGroup(t, &jamfpro.ComputerGroupRequest{
+						Name:      name,
+						Computers: []jamfpro.Computer{{SerialNumber: serialNumber}},
+					})
+				},
+				Config:             testAccComputerGroupConvertConfig(serialNumber, testAccComputerGroupConvertSmart(name, serialNumber)),
+				ResourceName:       "jamfpro_smartcomputergroup.test",
+				ImportState:        true,
+				ImportStatePersist: true,
+				ImportStateIdFunc:  func(*terraform.State) (string, error) { return strconv.Itoa(groupID), nil },
+			},
+			// and converted in place, keeping its ID
+			{
+				Config: testAccComputerGroupConvertConfig(serialNumber, testAccComputerGroupConvertSmart(name, serialNumber)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckComputerGroupIsSmart(t, "jamfpro_smartcomputergroup.test", &groupID, true),
+					resource.TestCheckResourceAttr("jamfpro_smartcomputergroup.test", "computers.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccComputerGroupResource_convertToStatic(t *testing.T) {
+	name := acctest.RandString(12)
+	serialNumber := randomSerialNumber()
+	var groupID int
+
+	static := fmt.Sprintf(`
+resource "jamfpro_computergroup" "test" {
+  name         = %q
+  computer_ids = [jamfpro_computer.test.id]
+}`, name)
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputerResourceConfig("Convert Test Mac", serialNumber),
+			},
+			// A smart group created outside of Terraform, whose only member is the
+			// computer, is imported as a static group
+			{
+				PreConfig: func() {
+					groupID = testAccCreateComputerGroup(t, &jamfpro.ComputerGroupRequest{
+						Name:    name,
+						IsSmart: true,
+						Criteria: []jamfpro.ComputerGroupCriteria{
+							{Name: "Serial Number", AndOr: "and", SearchType: "is", Value: serialNumber},
+						},
+					})
+				},
+				Config:             testAccComputerGroupConvertConfig(serialNumber, static),
+				ResourceName:       "jamfpro_computergroup.test",
+				ImportState:        true,
+				ImportStatePersist: true,
+				ImportStateIdFunc:  func(*terraform.State) (string, error) { return strconv.Itoa(groupID), nil },
+			},
+			// and converted in place, keeping its ID, although its members do not change
+			{
+				Config: testAccComputerGroupConvertConfig(serialNumber, static),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckComputerGroupIsSmart(t, "jamfpro_computergroup.test", &groupID, false),
+					resource.TestCheckTypeSetElemAttrPair("jamfpro_computergroup.test", "computer_ids.*", "jamfpro_computer.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCreateComputerGroup creates a computer group outside of Terraform and
+// returns its ID.
+func testAccCreateComputerGroup(t *testing.T, request *jamfpro.ComputerGroupRequest) int {
+	t.Helper()
+	group, _, err := testAccJamfProClient(t).ComputerGroups.Create(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return group.Id
+}
+
+// testAccCheckComputerGroupIsSmart checks that the group of a resource is the
+// one with ID *id, and whether it is a smart group. Static groups must not
+// have criteria.
+func testAccCheckComputerGroupIsSmart(t *testing.T, resourceName string, id *int, smart bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+		if rs.Primary.ID != strconv.Itoa(*id) {
+			return fmt.Errorf("%s has ID %s, want %d", resourceName, rs.Primary.ID, *id)
+		}
+		group, _, err := testAccJamfProClient(t).ComputerGroups.GetByID(context.Background(), *id)
+		if err != nil {
+			return err
+		}
+		if group.IsSmart != smart {
+			return fmt.Errorf("computer group %d has is_smart %t, want %t", *id, group.IsSmart, smart)
+		}
+		if !smart && len(group.Criteria) != 0 {
+			return fmt.Errorf("static computer group %d kept %d criteria", *id, len(group.Criteria))
+		}
+		return nil
+	}
+}
+
+// testAccComputerGroupConvertSmart returns a smart group whose only member is
+// the computer with the given serial number.
+func testAccComputerGroupConvertSmart(name string, serialNumber string) string {
+	return fmt.Sprintf(`
+resource "jamfpro_smartcomputergroup" "test" {
+  name     = %q
+  criteria = [
+    {
+      name        = "Serial Number"
+      and_or      = "and"
+      search_type = "is"
+      value       = %q
+    },
+  ]
+}`, name, serialNumber)
+}
+
+func testAccComputerGroupConvertConfig(serialNumber string, group string) string {
+	return testAccComputerResourceConfig("Convert Test Mac", serialNumber) + group
+}
+
+// testMoveState moves source, of sourceType and in the schema of the first
+// state mover of target, to the state of target.
+func testMoveState(t *testing.T, target fwresource.ResourceWithMoveState, sourceType string, source interface{}) fwresource.MoveStateResponse {
+	t.Helper()
+	ctx := context.Background()
+	mover := target.MoveState(ctx)[0]
+
+	sourceState := tfsdk.State{Schema: *mover.SourceSchema}
+	if diags := sourceState.Set(ctx, source); diags.HasError() {
+		t.Fatal(diags)
+	}
+	var schemaResponse fwresource.SchemaResponse
+	target.Schema(ctx, fwresource.SchemaRequest{}, &schemaResponse)
+	request := fwresource.MoveStateRequest{
+		SourceProviderAddress: "registry.terraform.io/jc0b/jamfpro",
+		SourceSchemaVersion:   mover.SourceSchema.Version,
+		SourceState:           &sourceState,
+		SourceTypeName:        sourceType,
+	}
+	response := fwresource.MoveStateResponse{TargetState: tfsdk.State{Schema: schemaResponse.Schema}}
+	mover.StateMover(ctx, request, &response)
+	if response.Diagnostics.HasError() {
+		t.Fatal(response.Diagnostics)
+	}
+	return response
+}
+
+func TestComputerGroupResourceMoveState(t *testing.T) {
+	ctx := context.Background()
+	criterion := types.ObjectValueMust(criteriaAttrTypes, map[string]attr.Value{
+		"name":          types.StringValue("Application Title"),
+		"priority":      types.Int64Value(0),
+		"and_or":        types.StringValue("and"),
+		"search_type":   types.StringValue("is"),
+		"value":         types.StringValue("Safari.app"),
+		"opening_paren": types.BoolValue(false),
+		"closing_paren": types.BoolValue(false),
+	})
+	source := smartcomputergroup{
+		Id:               types.Int64Value(3),
+		Name:             types.StringValue("Browsers"),
+		Criteria:         types.ListValueMust(types.ObjectType{AttrTypes: criteriaAttrTypes}, []attr.Value{criterion}),
+		Computers:        computersForState([]jamfpro.Computer{{Id: 1, Name: "Mac", SerialNumber: "C02AAAAAAAAA", Udid: "udid"}}),
+		IncludeComputers: types.BoolNull(),
+		Timeouts:         types.ObjectNull(timeoutsAttrTypes),
+	}
+
+	response := testMoveState(t, &ComputerGroupResource{}, "jamfpro_smartcomputergroup", source)
+	var moved computergroup
+	if diags := response.TargetState.Get(ctx, &moved); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if moved.Id.ValueInt64() != 3 || moved.Name.ValueString() != "Browsers" {
+		t.Errorf("moved group = %d %q, want 3 \"Browsers\"", moved.Id.ValueInt64(), moved.Name.ValueString())
+	}
+	if len(moved.ComputerIds.Elements()) != 0 || len(moved.SerialNumbers.Elements()) != 0 || len(moved.Computers.Elements()) != 0 {
+		t.Errorf("moved group has members %s, want none", moved.Computers)
+	}
+}
+
+func TestComputerGroupResourceMoveState_otherType(t *testing.T) {
+	ctx := context.Background()
+	r := &ComputerGroupResource{}
+	for _, mover := range r.MoveState(ctx) {
+		var response fwresource.MoveStateResponse
+		mover.StateMover(ctx, fwresource.MoveStateRequest{
+			SourceProviderAddress: "registry.terraform.io/jc0b/jamfpro",
+			SourceSchemaVersion:   mover.SourceSchema.Version,
+			SourceTypeName:        "jamfpro_computer",
+		}, &response)
+		if response.Diagnostics.HasError() || response.TargetState.Raw.Type() != nil {
+			t.Errorf("moving jamfpro_computer was not skipped: %v", response.Diagnostics)
+		}
+	}
+}
@@ -0,0 +1,375 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jc0b/go-jamfpro-api/jamfpro"
+	"strconv"
+	"strings"
+)
+
+var _ resource.Resource = &ComputerGroupMembershipResource{}
+var _ resource.ResourceWithImportState = &ComputerGroupMembershipResource{}
+
+func NewComputerGroupMembershipResource() resource.Resource {
+	return &ComputerGroupMembershipResource{}
+}
+
+// ComputerGroupMembershipResource manages some of the members of a static
+// computer group, leaving its other members to other configurations.
+type ComputerGroupMembershipResource struct {
+	client   *jamfpro.Client
+	api      *apiClient
+	retry    retryPolicy
+	readOnly bool
+}
+
+func (c *ComputerGroupMembershipResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_computergroup_membership"
+}
+
+func (c *ComputerGroupMembershipResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Represents computers that are members of a static computer group in Jamf Pro, without " +
+			"managing the group or its other members.",
+		MarkdownDescription: "This resource (`jamfpro_computergroup_membership`) adds computers to a static " +
+			"computer group in Jamf Pro, without managing the group or its other members. Use it to share a group " +
+			"between configurations, instead of the members of `jamfpro_computergroup`. Computers that are " +
+			"already members of the group are adopted, and removed from the group like the others when the " +
+			"resource is destroyed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the computer group.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"computer_group_id": schema.Int64Attribute{
+				Required:    true,
+				Description: "ID of the static computer group to add the computers to.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"computer_ids": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.Int64Type,
+				Description: "IDs of the computers this resource makes members of the group.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (c *ComputerGroupMembershipResource) Configure(ctx context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	data, ok := request.ProviderData.(*providerData)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	c.client = data.client
+	c.api = data.api
+	c.retry = data.retry
+	c.readOnly = data.readOnly
+}
+
+func (c *ComputerGroupMembershipResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	if c.readOnly {
+		response.Diagnostics.AddError(readOnlyError("create", "jamfpro_computergroup_membership"))
+		return
+	}
+
+	var data computergroupmembership
+
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var planned []int64
+	response.Diagnostics.Append(data.ComputerIds.ElementsAs(ctx, &planned, false)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	group, diags := c.changeMembers(ctx, data.ComputerGroupId.ValueInt64(), computerGroupMembershipChangeOf(nil, planned))
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a computergroup membership")
+
+	response.Diagnostics.Append(response.State.Set(ctx, computergroupmembership{
+		Id:              types.StringValue(strconv.Itoa(group.Id)),
+		ComputerGroupId: data.ComputerGroupId,
+		ComputerIds:     data.ComputerIds,
+	})...)
+}
+
+func (c *ComputerGroupMembershipResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var data computergroupmembership
+
+	// Read Terraform prior state data into the model
+	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var managed []int64
+	response.Diagnostics.Append(data.ComputerIds.ElementsAs(ctx, &managed, false)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	group, found, err := readJamfProObject(ctx, c.retry, c.client.ComputerGroups.GetByID, int(data.ComputerGroupId.ValueInt64()))
+
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Client error",
+			fmt.Sprintf("Unable to read computergroup with ID %d, got error: %s", data.ComputerGroupId.ValueInt64(), err),
+		)
+		return
+	}
+
+	if !found {
+		tflog.Warn(ctx, "computergroup no longer exists in Jamf Pro, removing its membership from state", map[string]interface{}{
+			"id": data.ComputerGroupId.ValueInt64(),
+		})
+		response.State.RemoveResource(ctx)
+		return
+	}
+
+	if group.IsSmart {
+		response.Diagnostics.AddAttributeError(
+			path.Root("computer_group_id"),
+			"Not a static computer group",
+			fmt.Sprintf("Computer group %q with ID %d is a smart group, whose members follow from its criteria.", group.Name, group.Id),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "read a computergroup membership")
+
+	// Save updated data into Terraform state
+	response.Diagnostics.Append(response.State.Set(ctx, computerGroupMembershipForState(group, managed))...)
+}
+
+func (c *ComputerGroupMembershipResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	if c.readOnly {
+		response.Diagnostics.AddError(readOnlyError("update", "jamfpro_computergroup_membership"))
+		return
+	}
+
+	var data, state computergroupmembership
+
+	// Read Terraform plan data into the model
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var prior, planned []int64
+	response.Diagnostics.Append(state.ComputerIds.ElementsAs(ctx, &prior, false)...)
+	response.Diagnostics.Append(data.ComputerIds.ElementsAs(ctx, &planned, false)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags := c.changeMembers(ctx, data.ComputerGroupId.ValueInt64(), computerGroupMembershipChangeOf(prior, planned))
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated a computergroup membership")
+
+	// Save updated data into Terraform state
+	data.Id = state.Id
+	response.Diagnostics.Append(response.State.Set(ctx, data)...)
+}
+
+func (c *ComputerGroupMembershipResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	if c.readOnly {
+		response.Diagnostics.AddError(readOnlyError("delete", "jamfpro_computergroup_membership"))
+		return
+	}
+
+	var data computergroupmembership
+
+	diags := request.State.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var managed []int64
+	response.Diagnostics.Append(data.ComputerIds.ElementsAs(ctx, &managed, false)...)
+	if response.Diagnostics.HasError() || len(managed) == 0 {
+		return
+	}
+
+	change := computerGroupMembershipChangeOf(managed, nil)
+	err := c.api.putXML(ctx, computerGroupMembershipPath(data.ComputerGroupId.ValueInt64()), change)
+	if err != nil && !isNotFoundError(err) {
+		response.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to remove computers from computergroup with ID %d, got error: %s", data.ComputerGroupId.ValueInt64(), err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a computergroup membership")
+}
+
+// ImportState imports the membership of computers in a group from an ID of
+// the form <computer_group_id>:<computer_id>,<computer_id>,...
+func (c *ComputerGroupMembershipResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	groupID, computerIDs, ok := strings.Cut(request.ID, ":")
+	group, err := strconv.ParseInt(groupID, 10, 64)
+	if !ok || err != nil || computerIDs == "" {
+		response.Diagnostics.AddError(
+			"Invalid resource ID",
+			"Jamf Pro computergroup_membership ID must be of the form <computer_group_id>:<computer_id>,<computer_id>,...",
+		)
+		return
+	}
+
+	var ids []int64
+	for _, computerID := range strings.Split(computerIDs, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(computerID), 10, 64)
+		if err != nil {
+			response.Diagnostics.AddError(
+				"Invalid resource ID",
+				fmt.Sprintf("Computer ID %q of the computergroup_membership ID must be an integer.", computerID),
+			)
+			return
+		}
+		ids = append(ids, id)
+	}
+	computers, diags := types.SetValueFrom(ctx, types.Int64Type, ids)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, computergroupmembership{
+		Id:              types.StringValue(strconv.FormatInt(group, 10)),
+		ComputerGroupId: types.Int64Value(group),
+		ComputerIds:     computers,
+	})...)
+}
+
+// changeMembers applies a change to the members of a static group, and waits
+// for Jamf Pro to serve it. It returns the group as last observed.
+func (c *ComputerGroupMembershipResource) changeMembers(ctx context.Context, groupId int64, change computerGroupMembershipChange) (*jamfpro.ComputerGroup, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	group, found, err := readJamfProObject(ctx, c.retry, c.client.ComputerGroups.GetByID, int(groupId))
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read computergroup with ID %d, got error: %s", groupId, err),
+		)
+		return nil, diags
+	}
+	if !found {
+		diags.AddAttributeError(
+			path.Root("computer_group_id"),
+			"No matching computer group",
+			fmt.Sprintf("No computer group with ID %d exists in Jamf Pro.", groupId),
+		)
+		return nil, diags
+	}
+	if group.IsSmart {
+		diags.AddAttributeError(
+			path.Root("computer_group_id"),
+			"Not a static computer group",
+			fmt.Sprintf("Computer group %q with ID %d is a smart group, whose members follow from its criteria.", group.Name, group.Id),
+		)
+		return nil, diags
+	}
+
+	// Computers that are already members are adopted: they are kept in state
+	// like those added, and removed from the group when the resource is
+	// destroyed.
+	members := computerGroupMemberIDs(group)
+	additions := make([]computerGroupMembershipItem, 0, len(change.Additions))
+	for _, a := range change.Additions {
+		if containsID(members, int64(a.Id)) {
+			tflog.Debug(ctx, "computer is already a member of the computergroup, adopting it", map[string]interface{}{
+				"computer_group_id": groupId,
+				"computer_id":       a.Id,
+			})
+			continue
+		}
+		additions = append(additions, a)
+	}
+	change.Additions = additions
+
+	if len(change.Additions) == 0 && len(change.Deletions) == 0 {
+		return group, diags
+	}
+	if err := c.api.putXML(ctx, computerGroupMembershipPath(groupId), change); err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to change the computers of computergroup with ID %d, got error: %s", groupId, err),
+		)
+		return nil, diags
+	}
+
+	tflog.Trace(ctx, "Waiting for computergroup membership to propagate in Jamf")
+	err = c.retry.poll(ctx, func() (bool, error) {
+		observed, resp, err := c.client.ComputerGroups.GetByID(ctx, int(groupId))
+		if isNotFound(resp) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		group = observed
+		return change.hasMembers(observed), nil
+	})
+	if errors.Is(err, errNotPropagated) {
+		diags.AddWarning(
+			"Computer group not propagated",
+			fmt.Sprintf("The computers of computergroup with ID %d were changed, but Jamf Pro does not serve the change yet.", groupId))
+	} else if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read computergroup with ID %d, got error: %s", groupId, err),
+		)
+	}
+	return group, diags
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccComputerGroupMembershipResource(t *testing.T) {
+	name := acctest.RandString(12)
+	resourceName := "jamfpro_computergroup_membership.test"
+	dataSourceName := "data.jamfpro_computergroup.test"
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read
+			{
+				Config: testAccComputerGroupMembershipResourceConfig(name, "[jamfpro_computer.test[1].id]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "id", "jamfpro_computergroup.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "computer_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "computer_ids.*", "jamfpro_computer.test.1", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "computers.#", "2"),
+				),
+			},
+			// ImportState
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccComputerGroupMembershipImportID(resourceName),
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "42",
+				ExpectError:   regexp.MustCompile(`must be of the form <computer_group_id>:<computer_id>`),
+			},
+			// Update and Read
+			{
+				Config: testAccComputerGroupMembershipResourceConfig(name, "[jamfpro_computer.test[1].id, jamfpro_computer.test[2].id]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "computer_ids.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "computers.#", "3"),
+				),
+			},
+			// Deleting the membership leaves the other members of the group
+			{
+				Config: testAccComputerGroupMembershipResourceConfig(name, ""),
+			},
+			// The data source is read again in the next plan, once the membership is deleted
+			{
+				Config: testAccComputerGroupMembershipResourceConfig(name, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "computers.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(dataSourceName, "computers.*.id", "jamfpro_computer.test.0", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccComputerGroupMembershipResource_existingMember(t *testing.T) {
+	name := acctest.RandString(12)
+	resourceName := "jamfpro_computergroup_membership.test"
+	dataSourceName := "data.jamfpro_computergroup.test"
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The computer already in the group is adopted
+			{
+				Config: testAccComputerGroupMembershipResourceConfig(name, "[jamfpro_computer.test[0].id, jamfpro_computer.test[1].id]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "computer_ids.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "computer_ids.*", "jamfpro_computer.test.0", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "computers.#", "2"),
+				),
+			},
+			// and removed from the group with the others
+			{
+				Config: testAccComputerGroupMembershipResourceConfig(name, ""),
+			},
+			{
+				Config: testAccComputerGroupMembershipResourceConfig(name, ""),
+				Check:  resource.TestCheckResourceAttr(dataSourceName, "computers.#", "0"),
+			},
+		},
+	})
+}
+
+// testAccComputerGroupMembershipImportID returns the import ID of a membership in state.
+func testAccComputerGroupMembershipImportID(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found in state", resourceName)
+		}
+		var ids []string
+		for key, value := range rs.Primary.Attributes {
+			if strings.HasPrefix(key, "computer_ids.") && key != "computer_ids.#" {
+				ids = append(ids, value)
+			}
+		}
+		return rs.Primary.Attributes["computer_group_id"] + ":" + strings.Join(ids, ","), nil
+	}
+}
+
+// testAccComputerGroupMembershipResourceConfig returns a configuration with
+// a group owned by another configuration, whose first computer it manages, and
+// a membership of the given computers in it, if any.
+func testAccComputerGroupMembershipResourceConfig(name string, computerIDs string) string {
+	membership := ""
+	dependsOn := "jamfpro_computergroup.test"
+	if computerIDs != "" {
+		membership = fmt.Sprintf(`
+resource "jamfpro_computergroup_membership" "test" {
+  computer_group_id = jamfpro_computergroup.test.id
+  computer_ids      = %s
+}`, computerIDs)
+		dependsOn = "jamfpro_computergroup_membership.test"
+	}
+
+	return fmt.Sprintf(`
+resource "jamfpro_computer" "test" {
+  count = 3
+  name  = "%[1]s-${count.index}"
+}
+
+resource "jamfpro_computergroup" "test" {
+  name         = %[1]q
+  computer_ids = [jamfpro_computer.test[0].id]
+
+  lifecycle {
+    ignore_changes = [computer_ids]
+  }
+}
+%[2]s
+
+data "jamfpro_computergroup" "test" {
+  id         = jamfpro_computergroup.test.id
+  depends_on = [%[3]s]
+}
+`, name, membership, dependsOn)
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
@@ -19,7 +20,10 @@ func NewDepartmentResource() resource.Resource {
 }
 
 type DepartmentResource struct {
-	client *jamfpro.Client
+	client   *jamfpro.Client
+	api      *apiClient
+	retry    retryPolicy
+	readOnly bool
 }
 
 func (c *DepartmentResource) Configure(ctx context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
@@ -28,18 +32,21 @@ func (c *DepartmentResource) Configure(ctx context.Context, request resource.Con
 		return
 	}
 
-	client, ok := request.ProviderData.(*jamfpro.Client)
+	data, ok := request.ProviderData.(*providerData)
 
 	if !ok {
 		response.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *jamfpro.Client, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
 		)
 
 		return
 	}
 
-	c.client = client
+	c.client = data.client
+	c.retry = data.retry
+	c.api = data.api
+	c.readOnly = data.readOnly
 }
 
 func (c *DepartmentResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
@@ -68,6 +75,11 @@ func (c *DepartmentResource) Schema(ctx context.Context, request resource.Schema
 }
 
 func (c *DepartmentResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	if c.readOnly {
+		response.Diagnostics.AddError(readOnlyError("create", "jamfpro_department"))
+		return
+	}
+
 	var data department
 
 	// Read Terraform plan data into the model
@@ -105,7 +117,7 @@ func (c *DepartmentResource) Read(ctx context.Context, request resource.ReadRequ
 		return
 	}
 
-	department, _, err := c.client.Departments.GetByID(ctx, int(data.Id.ValueInt64()))
+	department, found, err := readJamfProObject(ctx, c.retry, c.client.Departments.GetByID, int(data.Id.ValueInt64()))
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Client error",
@@ -114,6 +126,14 @@ func (c *DepartmentResource) Read(ctx context.Context, request resource.ReadRequ
 		return
 	}
 
+	if !found {
+		tflog.Warn(ctx, "department no longer exists in Jamf Pro, removing it from state", map[string]interface{}{
+			"id": data.Id.ValueInt64(),
+		})
+		response.State.RemoveResource(ctx)
+		return
+	}
+
 	tflog.Trace(ctx, "read a Department")
 
 	// Save updated data into Terraform state
@@ -121,6 +141,11 @@ func (c *DepartmentResource) Read(ctx context.Context, request resource.ReadRequ
 }
 
 func (c *DepartmentResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	if c.readOnly {
+		response.Diagnostics.AddError(readOnlyError("update", "jamfpro_department"))
+		return
+	}
+
 	var data department
 
 	// Read Terraform plan data into the model
@@ -149,6 +174,11 @@ func (c *DepartmentResource) Update(ctx context.Context, request resource.Update
 }
 
 func (c *DepartmentResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	if c.readOnly {
+		response.Diagnostics.AddError(readOnlyError("delete", "jamfpro_department"))
+		return
+	}
+
 	var data department
 
 	diags := request.State.Get(ctx, &data)
@@ -158,8 +188,8 @@ func (c *DepartmentResource) Delete(ctx context.Context, request resource.Delete
 		return
 	}
 
-	_, err := c.client.Departments.Delete(ctx, int(data.Id.ValueInt64()))
-	if err != nil {
+	resp, err := c.client.Departments.Delete(ctx, int(data.Id.ValueInt64()))
+	if err != nil && !isNotFound(resp) {
 		response.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to delete department with ID %d, got error: %s", data.Id.ValueInt64(), err),
@@ -171,5 +201,9 @@ func (c *DepartmentResource) Delete(ctx context.Context, request resource.Delete
 }
 
 func (c *DepartmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resourceImportStatePassthroughJamfProID(ctx, "department", req, resp)
+	resourceImportStatePassthroughJamfProID(ctx, "department", req, resp, map[string]importIDLookup{
+		"name": func(ctx context.Context, name string) (int, diag.Diagnostics) {
+			return lookUpIDByName(ctx, c.api, "/api/v1/departments", "name", "department", name)
+		},
+	})
 }
@@ -13,7 +13,7 @@ func TestAccDepartmentResource(t *testing.T) {
 	newName := acctest.RandString(12)
 	resourceName := "jamfpro_department.test"
 
-	resource.Test(t, resource.TestCase{
+	testAccRun(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
@@ -31,6 +31,13 @@ func TestAccDepartmentResource(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// ImportState by name
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "name:" + Name,
+				ImportStateVerify: true,
+			},
 			// Update and Read
 			{
 				Config: testAccDepartmentResourceConfig(newName),
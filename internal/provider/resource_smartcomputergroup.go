@@ -2,29 +2,36 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jc0b/go-jamfpro-api/jamfpro"
-	"net/http"
-	"time"
 )
 
 var _ resource.Resource = &SmartComputerGroupResource{}
 var _ resource.ResourceWithImportState = &SmartComputerGroupResource{}
+var _ resource.ResourceWithUpgradeState = &SmartComputerGroupResource{}
+var _ resource.ResourceWithValidateConfig = &SmartComputerGroupResource{}
+var _ resource.ResourceWithMoveState = &SmartComputerGroupResource{}
 
 func NewSmartComputerGroupResource() resource.Resource {
 	return &SmartComputerGroupResource{}
 }
 
 type SmartComputerGroupResource struct {
-	client *jamfpro.Client
+	client   *jamfpro.Client
+	retry    retryPolicy
+	readOnly bool
 }
 
 func (c SmartComputerGroupResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
@@ -34,9 +41,13 @@ func (c SmartComputerGroupResource) Metadata(ctx context.Context, request resour
 
 func (c SmartComputerGroupResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
 	response.Schema = schema.Schema{
+		Version:             1,
 		Description:         "Represents a Smart Computer Group resource in Jamf Pro",
 		MarkdownDescription: "This resource (`jamfpro_smartcomputergroup`) manages Smart Computer Groups in Jamf Pro",
 
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
 				Computed:    true,
@@ -49,10 +60,47 @@ func (c SmartComputerGroupResource) Schema(ctx context.Context, request resource
 				Required:    true,
 				Description: "Name of the Smart Computer Group",
 			},
-			"criteria": schema.SetNestedAttribute{
+			"computers": schema.SetNestedAttribute{
+				Computed:    true,
+				Description: "The computers that are currently members of the smart group. Null if include_computers is false.",
+				MarkdownDescription: "The computers that are currently members of the smart group. Null if " +
+					"`include_computers` is `false`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description:         "ID of the computer.",
+							MarkdownDescription: "`ID` of the computer.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							Description:         "Name of the computer.",
+							MarkdownDescription: "`name` of the computer.",
+							Computed:            true,
+						},
+						"serial_number": schema.StringAttribute{
+							Description:         "Serial number of the computer.",
+							MarkdownDescription: "`serial_number` of the computer.",
+							Computed:            true,
+						},
+						"udid": schema.StringAttribute{
+							Description:         "Hardware UDID of the computer.",
+							MarkdownDescription: "`udid` of the computer.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"include_computers": schema.BoolAttribute{
+				Optional: true,
+				Description: "Whether to keep the members of the smart group in computers. Defaults to true. " +
+					"Set it to false for very large groups.",
+				MarkdownDescription: "Whether to keep the members of the smart group in `computers`. Defaults to " +
+					"`true`. Set it to `false` for very large groups.",
+			},
+			"criteria": schema.ListNestedAttribute{
 				Required:    true,
 				Computed:    false,
-				Description: "Represents criteria by which members of a smart group are defined.",
+				Description: "Represents criteria by which members of a smart group are defined, in the order they are evaluated.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"name": schema.StringAttribute{
@@ -60,11 +108,16 @@ func (c SmartComputerGroupResource) Schema(ctx context.Context, request resource
 							Description: "Represents the name of a criteria to check against",
 						},
 						"priority": schema.Int64Attribute{
-							Optional:    true,
-							Description: "Represents this elements position in the order of criteria. Counting starts at 1.",
+							Optional: true,
+							Computed: true,
+							Description: "Represents this elements position in the order of criteria. Counting starts at 0. " +
+								"Defaults to the position of the criteria in the list.",
 							Validators: []validator.Int64{
 								int64validator.AtLeast(0),
 							},
+							PlanModifiers: []planmodifier.Int64{
+								criteriaPriorityFromPosition(),
+							},
 						},
 						"and_or": schema.StringAttribute{
 							Optional: true,
@@ -79,10 +132,13 @@ func (c SmartComputerGroupResource) Schema(ctx context.Context, request resource
 							Description: "Represents the operator used to assess the relationship between the criteria " +
 								"and the value fields.",
 							MarkdownDescription: "Represents the operator used to assess the relationship between the " +
-								"`name` and the `value` fields. Possible values are: `is`, `is not`, `has`, and `does " +
-								"not have`.",
+								"`name` and the `value` fields. Possible values are: " + searchTypesMarkdown() + ". " +
+								"The value of numeric operators must be a number or version, that of `x days ago` " +
+								"operators a number of days, that of date operators a date of the form `yyyy-mm-dd`, " +
+								"that of regex operators a valid regular expression, and that of `member of` operators " +
+								"the name of a group.",
 							Validators: []validator.String{
-								stringvalidator.OneOf("is", "is not", "has", "does not have"),
+								stringvalidator.OneOf(searchTypeNames()...),
 							},
 						},
 						"value": schema.StringAttribute{
@@ -110,20 +166,27 @@ func (c *SmartComputerGroupResource) Configure(ctx context.Context, request reso
 		return
 	}
 
-	client, ok := request.ProviderData.(*jamfpro.Client)
+	data, ok := request.ProviderData.(*providerData)
 
 	if !ok {
 		response.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *jamfpro.Client, got: #{request.ProviderData}. Please report this issue to the provider developers."),
+			fmt.Sprintf("Expected *providerData, got: #{request.ProviderData}. Please report this issue to the provider developers."),
 		)
 
 		return
 	}
 
-	c.client = client
+	c.client = data.client
+	c.retry = data.retry
+	c.readOnly = data.readOnly
 }
 
 func (c *SmartComputerGroupResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	if c.readOnly {
+		response.Diagnostics.AddError(readOnlyError("create", "jamfpro_smartcomputergroup"))
+		return
+	}
+
 	var data smartcomputergroup
 
 	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
@@ -132,6 +195,14 @@ func (c *SmartComputerGroupResource) Create(ctx context.Context, request resourc
 		return
 	}
 
+	timeout, diags := timeoutFor(data.Timeouts, "create")
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	computergroup, _, err := c.client.ComputerGroups.Create(ctx, smartComputerGroupRequestWithState(data))
 	if err != nil {
 		response.Diagnostics.AddError(
@@ -141,23 +212,24 @@ func (c *SmartComputerGroupResource) Create(ctx context.Context, request resourc
 	}
 
 	tflog.Trace(ctx, "Waiting for smartcomputergroup to propagate in Jamf")
-	createdSmartComputerGroup, resp, err := c.client.ComputerGroups.GetByID(ctx, computergroup.Id)
-	interval := 1
-	for resp.StatusCode != http.StatusOK && !AreGroupsEquivalent(computergroup, createdSmartComputerGroup) {
-		time.Sleep(time.Duration(interval) * time.Second)
-		createdSmartComputerGroup, resp, err = c.client.ComputerGroups.GetByID(ctx, computergroup.Id)
-		interval = interval * 2
+	observed, err := waitForComputerGroup(ctx, c.client, c.retry, computergroup)
+	if errors.Is(err, context.DeadlineExceeded) {
+		response.Diagnostics.AddError(computerGroupTimeoutError("smartcomputergroup", "creation", timeout, computergroup, observed))
+	} else if err != nil {
+		response.Diagnostics.AddWarning(
+			"Computer group propagation not checked",
+			fmt.Sprintf("Smartcomputergroup with ID %d was created, but checking that it propagated in Jamf Pro failed: %s", computergroup.Id, err))
 	}
 
 	tflog.Trace(ctx, "created a smartcomputergroup")
 
-	response.Diagnostics.Append(response.State.Set(ctx, smartComputerGroupForState(computergroup))...)
+	state := smartComputerGroupForState(computergroup).withSettingsOf(data)
+	response.Diagnostics.Append(response.State.Set(ctx, state)...)
 
 }
 
 func (c *SmartComputerGroupResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
 	var data smartcomputergroup
-	retryCount := 3
 
 	// Read Terraform prior state data into the model
 	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
@@ -166,14 +238,15 @@ func (c *SmartComputerGroupResource) Read(ctx context.Context, request resource.
 		return
 	}
 
-	smartComputerGroup, resp, err := c.client.ComputerGroups.GetByID(ctx, int(data.Id.ValueInt64()))
-	if resp.StatusCode == 404 {
-		for resp.StatusCode == 404 && retryCount > 0 {
-			time.Sleep(time.Duration(2) * time.Second)
-			smartComputerGroup, resp, err = c.client.ComputerGroups.GetByID(ctx, int(data.Id.ValueInt64()))
-			retryCount = retryCount - 1
-		}
+	timeout, diags := timeoutFor(data.Timeouts, "read")
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	smartComputerGroup, found, err := readJamfProObject(ctx, c.retry, c.client.ComputerGroups.GetByID, int(data.Id.ValueInt64()))
 
 	if err != nil {
 		response.Diagnostics.AddError(
@@ -183,15 +256,36 @@ func (c *SmartComputerGroupResource) Read(ctx context.Context, request resource.
 		return
 	}
 
+	if !found {
+		tflog.Warn(ctx, "smartcomputergroup no longer exists in Jamf Pro, removing it from state", map[string]interface{}{
+			"id": data.Id.ValueInt64(),
+		})
+		response.State.RemoveResource(ctx)
+		return
+	}
+
 	tflog.Trace(ctx, "read a smartcomputergroup")
+	if !smartComputerGroup.IsSmart {
+		// A static group has no criteria, so the configured criteria plan an
+		// update converting it to a smart group, keeping its ID.
+		tflog.Info(ctx, "smartcomputergroup is a static group, planning its conversion to a smart group", map[string]interface{}{
+			"id": data.Id.ValueInt64(),
+		})
+	}
 
 	// Save updated data into Terraform state
-	response.Diagnostics.Append(response.State.Set(ctx, smartComputerGroupForState(smartComputerGroup))...)
+	state := smartComputerGroupForState(smartComputerGroup).withSettingsOf(data)
+	response.Diagnostics.Append(response.State.Set(ctx, state)...)
 }
 
 func (c *SmartComputerGroupResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	if c.readOnly {
+		response.Diagnostics.AddError(readOnlyError("update", "jamfpro_smartcomputergroup"))
+		return
+	}
+
 	var data smartcomputergroup
-	retryCount := 5
+
 	// Read Terraform plan data into the model
 	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
 
@@ -199,16 +293,17 @@ func (c *SmartComputerGroupResource) Update(ctx context.Context, request resourc
 		return
 	}
 
+	timeout, diags := timeoutFor(data.Timeouts, "update")
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	smartComputerGroupUpdateRequest := smartComputerGroupRequestWithState(data)
 
-	smartComputerGroup, resp, err := c.client.ComputerGroups.Update(ctx, int(data.Id.ValueInt64()), smartComputerGroupUpdateRequest)
-	if resp.StatusCode == 404 {
-		for resp.StatusCode == 404 && retryCount > 0 {
-			time.Sleep(time.Duration(2) * time.Second)
-			_, resp, err = c.client.ComputerGroups.Update(ctx, int(data.Id.ValueInt64()), smartComputerGroupUpdateRequest)
-			retryCount = retryCount - 1
-		}
-	}
+	smartComputerGroup, _, err := c.client.ComputerGroups.Update(ctx, int(data.Id.ValueInt64()), smartComputerGroupUpdateRequest)
 
 	if err != nil {
 		response.Diagnostics.AddError(
@@ -218,23 +313,29 @@ func (c *SmartComputerGroupResource) Update(ctx context.Context, request resourc
 		return
 	}
 
-	tflog.Trace(ctx, "Waiting for computergroup to propagate in Jamf")
-	updatedSmartComputerGroup, _, err := c.client.ComputerGroups.GetByID(ctx, smartComputerGroup.Id)
-	interval := 1
-	for !AreGroupsEquivalent(smartComputerGroup, updatedSmartComputerGroup) {
-		time.Sleep(time.Duration(interval) * time.Second)
-		updatedSmartComputerGroup, _, err = c.client.ComputerGroups.GetByID(ctx, smartComputerGroup.Id)
-		interval = interval * 2
+	tflog.Trace(ctx, "Waiting for smartcomputergroup to propagate in Jamf")
+	observed, err := waitForComputerGroup(ctx, c.client, c.retry, smartComputerGroup)
+	if errors.Is(err, context.DeadlineExceeded) {
+		response.Diagnostics.AddError(computerGroupTimeoutError("smartcomputergroup", "update", timeout, smartComputerGroup, observed))
+	} else if err != nil {
+		response.Diagnostics.AddWarning(
+			"Computer group propagation not checked",
+			fmt.Sprintf("Smartcomputergroup with ID %d was updated, but checking that it propagated in Jamf Pro failed: %s", smartComputerGroup.Id, err))
 	}
-	time.Sleep(time.Duration(interval) * time.Second)
 
 	tflog.Trace(ctx, "updated a smartcomputergroup")
 
 	// Save updated data into Terraform state
-	response.Diagnostics.Append(response.State.Set(ctx, computerGroupForState(smartComputerGroup))...)
+	state := smartComputerGroupForState(smartComputerGroup).withSettingsOf(data)
+	response.Diagnostics.Append(response.State.Set(ctx, state)...)
 }
 
 func (c *SmartComputerGroupResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	if c.readOnly {
+		response.Diagnostics.AddError(readOnlyError("delete", "jamfpro_smartcomputergroup"))
+		return
+	}
+
 	var data smartcomputergroup
 
 	diags := request.State.Get(ctx, &data)
@@ -244,8 +345,16 @@ func (c *SmartComputerGroupResource) Delete(ctx context.Context, request resourc
 		return
 	}
 
-	_, err := c.client.ComputerGroups.Delete(ctx, int(data.Id.ValueInt64()))
-	if err != nil {
+	timeout, diags := timeoutFor(data.Timeouts, "delete")
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resp, err := c.client.ComputerGroups.Delete(ctx, int(data.Id.ValueInt64()))
+	if err != nil && !isNotFound(resp) {
 		response.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to delete smartcomputergroup with ID %d, got error: %s", data.Id.ValueInt64(), err),
@@ -257,5 +366,71 @@ func (c *SmartComputerGroupResource) Delete(ctx context.Context, request resourc
 }
 
 func (c *SmartComputerGroupResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
-	resourceImportStatePassthroughJamfProID(ctx, "smartcomputergroup", request, response)
+	resourceImportStatePassthroughJamfProID(ctx, "smartcomputergroup", request, response, map[string]importIDLookup{
+		"name": func(ctx context.Context, name string) (int, diag.Diagnostics) {
+			return lookUpComputerGroupIDByName(ctx, c.client, name)
+		},
+	})
+}
+
+func (c *SmartComputerGroupResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var criteria types.List
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("criteria"), &criteria)...)
+	if response.Diagnostics.HasError() || criteria.IsNull() || criteria.IsUnknown() {
+		return
+	}
+
+	for _, element := range criteria.Elements() {
+		if element.IsUnknown() {
+			return
+		}
+	}
+	var elements []criterion
+	response.Diagnostics.Append(criteria.ElementsAs(ctx, &elements, false)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(validateCriteria(elements, path.Root("criteria"))...)
+}
+
+func (c *SmartComputerGroupResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 kept the criteria in a set, which has no order.
+		0: {
+			PriorSchema: &schema.Schema{
+				Blocks: map[string]schema.Block{
+					"timeouts": timeoutsBlock(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"id":   schema.Int64Attribute{Computed: true},
+					"name": schema.StringAttribute{Required: true},
+					"criteria": schema.SetNestedAttribute{
+						Required: true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"name":          schema.StringAttribute{Optional: true},
+								"priority":      schema.Int64Attribute{Optional: true},
+								"and_or":        schema.StringAttribute{Optional: true},
+								"search_type":   schema.StringAttribute{Optional: true},
+								"value":         schema.StringAttribute{Optional: true},
+								"opening_paren": schema.BoolAttribute{Optional: true},
+								"closing_paren": schema.BoolAttribute{Optional: true},
+							},
+						},
+					},
+				},
+			},
+			StateUpgrader: upgradeSmartComputerGroupStateV0,
+		},
+	}
+}
+
+// MoveState moves a jamfpro_computergroup to this resource with a moved block.
+// The next apply converts the group to a smart group, keeping its ID.
+func (c *SmartComputerGroupResource) MoveState(ctx context.Context) []resource.StateMover {
+	var source resource.SchemaResponse
+	ComputerGroupResource{}.Schema(ctx, resource.SchemaRequest{}, &source)
+	return []resource.StateMover{
+		computerGroupStateMover(source.Schema, "jamfpro_computergroup", moveComputerGroupState),
+	}
 }
@@ -1,10 +1,16 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"github.com/jc0b/go-jamfpro-api/jamfpro"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
@@ -24,7 +30,7 @@ func TestAccSmartComputerGroupResource(t *testing.T) {
 	}
 	resourceName := "jamfpro_smartcomputergroup.test"
 
-	resource.Test(t, resource.TestCase{
+	testAccRun(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
@@ -42,6 +48,13 @@ func TestAccSmartComputerGroupResource(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// ImportState by name
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "name:" + Name,
+				ImportStateVerify: true,
+			},
 			// Update and Read
 			{
 				Config: testAccSmartComputerGroupResourceConfig(newName, testCriteria),
@@ -71,3 +84,291 @@ resource "jamfpro_smartcomputergroup" "test" {
   ]
 }`, name, criteria.AndOr, criteria.ClosingParen, criteria.Name, criteria.OpeningParen, criteria.Priority, criteria.SearchType, criteria.Value)
 }
+
+func TestAccSmartComputerGroupResource_propagationDelay(t *testing.T) {
+	Name := acctest.RandString(12)
+	newName := acctest.RandString(12)
+
+	testCriteria := jamfpro.ComputerGroupCriteria{
+		Name:       "Application Title",
+		AndOr:      "and",
+		SearchType: "is",
+		Value:      "Safari.app",
+	}
+	resourceName := "jamfpro_smartcomputergroup.test"
+
+	// Created and updated groups are served as missing or stale for the next
+	// two reads, as happens on a busy tenant.
+	server := testAccMockServer(t)
+	server.SetPropagationDelay(2)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSmartComputerGroupResourceConfig(Name, testCriteria),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						resourceName, "name", Name),
+				),
+			},
+			{
+				Config: testAccSmartComputerGroupResourceConfig(newName, testCriteria),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						resourceName, "name", newName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSmartComputerGroupResource_createTimeout(t *testing.T) {
+	Name := acctest.RandString(12)
+
+	// The group never propagates, and is checked for until the timeout, however
+	// few attempts retried requests get.
+	server := testAccMockServer(t)
+	server.SetPropagationDelay(1000)
+	t.Setenv("JAMF_RETRY_MAX_ATTEMPTS", "1")
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "jamfpro_smartcomputergroup" "test" {
+  name     = %q
+  criteria = [
+	{
+		and_or = "and"
+		name = "Application Title"
+		priority = 0
+		search_type = "is"
+		value = "Safari.app"
+	},
+  ]
+
+  timeouts {
+    create = "2s"
+  }
+}`, Name),
+				ExpectError: regexp.MustCompile(`(?s)smartcomputergroup "` + Name + `" with ID \d+ did not propagate.*within 2s.*the group was not found`),
+			},
+		},
+	})
+}
+
+func TestAccSmartComputerGroupResource_criteriaOrder(t *testing.T) {
+	Name := acctest.RandString(12)
+	resourceName := "jamfpro_smartcomputergroup.test"
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSmartComputerGroupResourceOrderedConfig(Name, "Safari.app", "Firefox.app", "Chrome.app"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "criteria.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "criteria.0.value", "Safari.app"),
+					resource.TestCheckResourceAttr(resourceName, "criteria.0.priority", "0"),
+					resource.TestCheckResourceAttr(resourceName, "criteria.2.value", "Chrome.app"),
+					resource.TestCheckResourceAttr(resourceName, "criteria.2.priority", "2"),
+				),
+			},
+			// Reordering the criteria changes their priorities
+			{
+				Config: testAccSmartComputerGroupResourceOrderedConfig(Name, "Chrome.app", "Safari.app", "Firefox.app"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "criteria.0.value", "Chrome.app"),
+					resource.TestCheckResourceAttr(resourceName, "criteria.0.priority", "0"),
+					resource.TestCheckResourceAttr(resourceName, "criteria.1.value", "Safari.app"),
+					resource.TestCheckResourceAttr(resourceName, "criteria.1.priority", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSmartComputerGroupResourceOrderedConfig(name string, values ...string) string {
+	criteria := ""
+	for i, value := range values {
+		andOr := "or"
+		if i == 0 {
+			andOr = "and"
+		}
+		criteria += fmt.Sprintf(`
+	{
+		and_or = %q
+		name = "Application Title"
+		search_type = "is"
+		value = %q
+	},`, andOr, value)
+	}
+	return fmt.Sprintf(`
+resource "jamfpro_smartcomputergroup" "test" {
+  name     = %q
+  criteria = [%s
+  ]
+}`, name, criteria)
+}
+
+func TestUpgradeSmartComputerGroupStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &SmartComputerGroupResource{}
+	upgrader := r.UpgradeState(ctx)[0]
+
+	criterionType := types.ObjectType{AttrTypes: criteriaAttrTypes}
+	criterion := func(value string, priority int64) attr.Value {
+		return types.ObjectValueMust(criteriaAttrTypes, map[string]attr.Value{
+			"name":          types.StringValue("Application Title"),
+			"priority":      types.Int64Value(priority),
+			"and_or":        types.StringValue("or"),
+			"search_type":   types.StringValue("is"),
+			"value":         types.StringValue(value),
+			"opening_paren": types.BoolValue(false),
+			"closing_paren": types.BoolValue(false),
+		})
+	}
+	prior := tfsdk.State{Schema: *upgrader.PriorSchema}
+	diags := prior.Set(ctx, smartcomputergroupV0{
+		Id:       types.Int64Value(3),
+		Name:     types.StringValue("Browsers"),
+		Criteria: types.SetValueMust(criterionType, []attr.Value{criterion("Firefox.app", 2), criterion("Safari.app", 0), criterion("Chrome.app", 1)}),
+		Timeouts: types.ObjectNull(timeoutsAttrTypes),
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	var schemaResponse fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResponse)
+	request := fwresource.UpgradeStateRequest{State: &prior}
+	response := fwresource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResponse.Schema}}
+	upgrader.StateUpgrader(ctx, request, &response)
+	if response.Diagnostics.HasError() {
+		t.Fatal(response.Diagnostics)
+	}
+
+	var upgraded smartcomputergroup
+	if diags := response.State.Get(ctx, &upgraded); diags.HasError() {
+		t.Fatal(diags)
+	}
+	want := types.ListValueMust(criterionType, []attr.Value{criterion("Safari.app", 0), criterion("Chrome.app", 1), criterion("Firefox.app", 2)})
+	if !upgraded.Criteria.Equal(want) {
+		t.Errorf("upgraded criteria = %s, want %s", upgraded.Criteria, want)
+	}
+	if upgraded.Id.ValueInt64() != 3 || upgraded.Name.ValueString() != "Browsers" {
+		t.Errorf("upgraded group = %d %q, want 3 \"Browsers\"", upgraded.Id.ValueInt64(), upgraded.Name.ValueString())
+	}
+}
+
+func TestSmartComputerGroupResourceMoveState(t *testing.T) {
+	ctx := context.Background()
+	source := computerGroupForState(&jamfpro.ComputerGroup{
+		Id:        3,
+		Name:      "Browsers",
+		Computers: []jamfpro.Computer{{Id: 1, Name: "Mac", SerialNumber: "C02AAAAAAAAA", Udid: "udid"}},
+	})
+
+	response := testMoveState(t, &SmartComputerGroupResource{}, "jamfpro_computergroup", source)
+	var moved smartcomputergroup
+	if diags := response.TargetState.Get(ctx, &moved); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if moved.Id.ValueInt64() != 3 || moved.Name.ValueString() != "Browsers" {
+		t.Errorf("moved group = %d %q, want 3 \"Browsers\"", moved.Id.ValueInt64(), moved.Name.ValueString())
+	}
+	if len(moved.Criteria.Elements()) != 0 || !moved.Computers.IsNull() {
+		t.Errorf("moved group = %s %s, want no criteria and null computers", moved.Criteria, moved.Computers)
+	}
+}
+
+func TestAccSmartComputerGroupResource_invalidCriteria(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "jamfpro_smartcomputergroup" "test" {
+  name     = "invalid"
+  criteria = [
+	{
+		name = "application title"
+		search_type = "is"
+		value = "Safari.app"
+		closing_paren = true
+	},
+  ]
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`(?s)Criteria 0 closes a parenthesis.*Did you mean "Application Title"\?`),
+			},
+		},
+	})
+}
+
+func TestAccSmartComputerGroupResource_computers(t *testing.T) {
+	prefix := acctest.RandString(12)
+	resourceName := "jamfpro_smartcomputergroup.test"
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSmartComputerGroupResourceComputersConfig(prefix, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "computers.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "computers.*.id", "jamfpro_computer.test.0", "id"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "computers.*.serial_number", "jamfpro_computer.test.1", "serial_number"),
+				),
+			},
+			// Membership is refreshed without causing a diff
+			{
+				Config:             testAccSmartComputerGroupResourceComputersConfig(prefix, ""),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config: testAccSmartComputerGroupResourceComputersConfig(prefix, "include_computers = false"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "include_computers", "false"),
+					resource.TestCheckNoResourceAttr(resourceName, "computers.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSmartComputerGroupResourceComputersConfig(prefix string, settings string) string {
+	return fmt.Sprintf(`
+resource "jamfpro_computer" "test" {
+  count = 2
+  name  = "%[1]s-${count.index}"
+}
+
+resource "jamfpro_smartcomputergroup" "test" {
+  name     = %[1]q
+  criteria = [
+	{
+		and_or = "and"
+		name = "Computer Name"
+		search_type = "like"
+		value = %[1]q
+	},
+  ]
+  %[2]s
+
+  depends_on = [jamfpro_computer.test]
+}`, prefix, settings)
+}
@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultRetryStatusCodes are the response status codes retried unless configured otherwise.
+var defaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// errNotPropagated is returned by retryPolicy.poll when the attempts ran out.
+var errNotPropagated = errors.New("change did not propagate in Jamf Pro in time")
+
+// retryPolicy decides how often and how long to wait before a failed request
+// is sent again. It is also used to wait for changes to propagate through a
+// Jamf Pro cluster.
+type retryPolicy struct {
+	// maxAttempts is the number of times a request is sent at most, the first one included.
+	maxAttempts int
+	// minBackoff is the wait before the first retry. It doubles with every retry.
+	minBackoff time.Duration
+	// maxBackoff bounds the wait between two attempts, unless the server asks for a longer one.
+	maxBackoff  time.Duration
+	statusCodes map[int]bool
+}
+
+func newRetryPolicy(maxAttempts int, maxBackoff time.Duration, statusCodes []int) retryPolicy {
+	policy := retryPolicy{
+		maxAttempts: maxAttempts,
+		minBackoff:  time.Second,
+		maxBackoff:  maxBackoff,
+		statusCodes: make(map[int]bool, len(statusCodes)),
+	}
+	if policy.minBackoff > maxBackoff {
+		policy.minBackoff = maxBackoff
+	}
+	for _, code := range statusCodes {
+		policy.statusCodes[code] = true
+	}
+	return policy
+}
+
+func defaultRetryPolicy() retryPolicy {
+	return newRetryPolicy(5, 30*time.Second, defaultRetryStatusCodes)
+}
+
+// backoff returns how long to wait before the retry following the given
+// attempt, counting from 1. A Retry-After header in response takes precedence.
+func (p retryPolicy) backoff(attempt int, response *http.Response) time.Duration {
+	if response != nil {
+		if wait, ok := retryAfter(response.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	wait := p.maxBackoff
+	if shift := attempt - 1; shift < 30 && p.minBackoff<<shift < p.maxBackoff {
+		wait = p.minBackoff << shift
+	}
+	// Spread out the retries of requests that failed together.
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// retryAfter parses the value of a Retry-After header, given in seconds or as an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// lastAttemptTime is the time left for a last attempt before the deadline of
+// a context, when the backoff before it would pass the deadline.
+const lastAttemptTime = time.Second
+
+// sleep waits for d. If ctx has a deadline before d has passed, it only waits
+// until lastAttemptTime before the deadline, so that there is time for a last
+// attempt, and returns context.DeadlineExceeded if that time has come already.
+// It returns the context's error when ctx is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline) - lastAttemptTime; remaining < d {
+			if remaining <= 0 {
+				return context.DeadlineExceeded
+			}
+			d = remaining
+		}
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// poll calls check until it reports done, waiting between the calls as between
+// retried requests. It returns errNotPropagated when the attempts run out, and
+// the context's error when ctx is done first.
+func (p retryPolicy) poll(ctx context.Context, check func() (done bool, err error)) error {
+	return p.pollAttempts(ctx, p.maxAttempts, check)
+}
+
+// waitFor calls check like poll, but until ctx is done rather than until the
+// attempts run out, for changes that are worth waiting for as long as the
+// timeout of the operation allows.
+func (p retryPolicy) waitFor(ctx context.Context, check func() (done bool, err error)) error {
+	return p.pollAttempts(ctx, 0, check)
+}
+
+// pollAttempts calls check at most maxAttempts times, or until ctx is done if
+// maxAttempts is 0.
+func (p retryPolicy) pollAttempts(ctx context.Context, maxAttempts int, check func() (done bool, err error)) error {
+	for attempt := 1; ; attempt++ {
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+		if maxAttempts > 0 && attempt >= maxAttempts {
+			return errNotPropagated
+		}
+		if err := sleep(ctx, p.backoff(attempt, nil)); err != nil {
+			return err
+		}
+	}
+}
+
+// retryTransport sends requests again when they fail with a retryable status
+// code, or with a network error if they are idempotent. Requests that are not
+// idempotent are only retried on 429 and 503 responses.
+type retryTransport struct {
+	base   http.RoundTripper
+	policy retryPolicy
+}
+
+func newRetryTransport(base http.RoundTripper, policy retryPolicy) *retryTransport {
+	return &retryTransport{base: base, policy: policy}
+}
+
+func (t *retryTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	canRewind := request.Body == nil || request.Body == http.NoBody || request.GetBody != nil
+
+	attempt := request
+	for n := 1; ; n++ {
+		response, err := t.base.RoundTrip(attempt)
+		if n >= t.policy.maxAttempts || !canRewind || !t.retryable(request, response, err) {
+			return response, err
+		}
+		next, rewindErr := rewindRequest(request)
+		if rewindErr != nil {
+			return response, err
+		}
+
+		// Only the status and delay of the response are kept, as it is closed
+		// before waiting so that it does not hold a request slot meanwhile.
+		wait := t.policy.backoff(n, response)
+		status := 0
+		if response != nil {
+			status = response.StatusCode
+			drainAndClose(response)
+		}
+		if sleepErr := sleep(request.Context(), wait); sleepErr != nil {
+			if err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%s %s returned status %d, and was not retried: %w",
+				request.Method, request.URL.Redacted(), status, sleepErr)
+		}
+		attempt = next
+	}
+}
+
+func (t *retryTransport) retryable(request *http.Request, response *http.Response, err error) bool {
+	if err != nil {
+		return request.Context().Err() == nil && idempotent(request.Method)
+	}
+	if !t.policy.statusCodes[response.StatusCode] {
+		return false
+	}
+	// A gateway may fail after Jamf Pro processed a request, so requests that
+	// are not idempotent are only retried when it refused to process them.
+	return idempotent(request.Method) ||
+		response.StatusCode == http.StatusTooManyRequests || response.StatusCode == http.StatusServiceUnavailable
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
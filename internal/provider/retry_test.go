@@ -0,0 +1,244 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jc0b/terraform-provider-jamfpro/internal/jamfmock"
+)
+
+func testRetryClient(t *testing.T, server *jamfmock.Server, maxAttempts int) *http.Client {
+	t.Helper()
+	policy := newRetryPolicy(maxAttempts, 50*time.Millisecond, defaultRetryStatusCodes)
+	policy.minBackoff = 10 * time.Millisecond
+	client := testAuthClient(t, server)
+	client.Transport = newRetryTransport(client.Transport, policy)
+	return client
+}
+
+func TestRetryTransportRetriesStatusCodes(t *testing.T) {
+	server := jamfmock.NewServer()
+	defer server.Close()
+	client := testRetryClient(t, server, 5)
+
+	server.InjectFault(jamfmock.Fault{Method: http.MethodGet, Path: "/api/v1/buildings", Status: http.StatusBadGateway, Count: 2})
+	server.InjectFault(jamfmock.Fault{Method: http.MethodPost, Path: "/api/v1/buildings", Status: http.StatusServiceUnavailable, Count: 2})
+
+	response, err := client.Get(server.URL + "/api/v1/buildings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("GET returned %d", response.StatusCode)
+	}
+
+	response, err = client.Post(server.URL+"/api/v1/buildings", "application/json", strings.NewReader(`{"name": "HQ"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		t.Errorf("POST returned %d", response.StatusCode)
+	}
+
+	if got := countRequests(server, "/api/v1/buildings"); got != 6 {
+		t.Errorf("%d requests, want 6", got)
+	}
+}
+
+func TestRetryTransportNonIdempotent(t *testing.T) {
+	server := jamfmock.NewServer()
+	defer server.Close()
+	client := testRetryClient(t, server, 5)
+
+	// The gateway may have failed after the building was created.
+	server.InjectFault(jamfmock.Fault{Method: http.MethodPost, Path: "/api/v1/buildings", Status: http.StatusBadGateway, Count: 1})
+	server.InjectFault(jamfmock.Fault{Method: http.MethodPost, Path: "/api/v1/departments", Status: http.StatusGatewayTimeout, Count: 1})
+
+	for _, path := range []string{"/api/v1/buildings", "/api/v1/departments"} {
+		response, err := client.Post(server.URL+path, "application/json", strings.NewReader(`{"name": "HQ"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusBadGateway && response.StatusCode != http.StatusGatewayTimeout {
+			t.Errorf("POST %s returned %d, want the injected fault", path, response.StatusCode)
+		}
+		if got := countRequests(server, path); got != 1 {
+			t.Errorf("POST %s: %d requests, want 1", path, got)
+		}
+	}
+}
+
+func TestRetryTransportHonoursRetryAfter(t *testing.T) {
+	server := jamfmock.NewServer()
+	defer server.Close()
+	client := testRetryClient(t, server, 5)
+
+	server.InjectFault(jamfmock.Fault{Path: "/api/v1/departments", Status: http.StatusTooManyRequests, Count: 1, RetryAfter: time.Second})
+
+	start := time.Now()
+	response, err := client.Get(server.URL + "/api/v1/departments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("request returned %d", response.StatusCode)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, before the Retry-After delay", elapsed)
+	}
+}
+
+func TestRetryTransportReleasesLimitDuringBackoff(t *testing.T) {
+	server := jamfmock.NewServer()
+	defer server.Close()
+
+	// A single request may be in flight, so a response held during the
+	// Retry-After delay would block every other request.
+	tokens := &tokenSource{
+		fetch: func(ctx context.Context) (sessionToken, error) {
+			return fetchOAuthToken(ctx, http.DefaultClient, server.URL, jamfmock.ClientID, jamfmock.ClientSecret)
+		},
+	}
+	transport, err := newAuthTransport(newLimitTransport(defaultTransport, newRequestLimiter(1, 0)), server.URL, tokens)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: newRetryTransport(transport, newRetryPolicy(5, time.Minute, defaultRetryStatusCodes))}
+
+	server.InjectFault(jamfmock.Fault{Path: "/api/v1/departments", Status: http.StatusTooManyRequests, Count: 1, RetryAfter: 2 * time.Second})
+	retried := make(chan error, 1)
+	go func() {
+		response, err := client.Get(server.URL + "/api/v1/departments")
+		if err == nil {
+			response.Body.Close()
+		}
+		retried <- err
+	}()
+	for countRequests(server, "/api/v1/departments") == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	request, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/v1/buildings", nil)
+	response, err := client.Do(request)
+	if err != nil {
+		t.Fatalf("request during the backoff of another: %s", err)
+	}
+	response.Body.Close()
+
+	if err := <-retried; err != nil {
+		t.Fatal(err)
+	}
+	if got := countRequests(server, "/api/v1/departments"); got != 2 {
+		t.Errorf("%d requests, want 2", got)
+	}
+}
+
+func TestRetryTransportGivesUp(t *testing.T) {
+	server := jamfmock.NewServer()
+	defer server.Close()
+	client := testRetryClient(t, server, 3)
+
+	server.InjectFault(jamfmock.Fault{Path: "/api/v1/categories", Status: http.StatusServiceUnavailable, Count: 10})
+	server.InjectFault(jamfmock.Fault{Path: "/api/v1/departments", Status: http.StatusInternalServerError, Count: 10})
+
+	for path, want := range map[string]int{"/api/v1/categories": 3, "/api/v1/departments": 1} {
+		response, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		response.Body.Close()
+		if got := countRequests(server, path); got != want {
+			t.Errorf("%s: %d requests, want %d", path, got, want)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	for value, want := range map[string]time.Duration{
+		"":                              0,
+		"3":                             3 * time.Second,
+		"-1":                            0,
+		"soon":                          0,
+		"Wed, 21 Oct 2015 07:28:00 GMT": 0,
+		time.Now().Add(time.Hour).UTC().Format(http.TimeFormat): time.Hour,
+	} {
+		got, _ := retryAfter(value)
+		if got > want || got < want-time.Minute {
+			t.Errorf("retryAfter(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := newRetryPolicy(10, 8*time.Second, defaultRetryStatusCodes)
+	for attempt, limit := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 4: 8 * time.Second, 9: 8 * time.Second} {
+		for i := 0; i < 20; i++ {
+			if got := policy.backoff(attempt, nil); got < limit/2 || got > limit {
+				t.Errorf("backoff(%d) = %s, want between %s and %s", attempt, got, limit/2, limit)
+			}
+		}
+	}
+}
+
+func TestRetryPolicyPoll(t *testing.T) {
+	policy := newRetryPolicy(3, time.Millisecond, nil)
+
+	calls := 0
+	err := policy.poll(context.Background(), func() (bool, error) {
+		calls++
+		return calls == 2, nil
+	})
+	if err != nil || calls != 2 {
+		t.Errorf("poll() = %v after %d calls, want success after 2", err, calls)
+	}
+
+	calls = 0
+	err = policy.poll(context.Background(), func() (bool, error) {
+		calls++
+		return false, nil
+	})
+	if !errors.Is(err, errNotPropagated) || calls != 3 {
+		t.Errorf("poll() = %v after %d calls, want errNotPropagated after 3", err, calls)
+	}
+}
+
+func TestRetryPolicyWaitFor(t *testing.T) {
+	policy := newRetryPolicy(1, 10*time.Millisecond, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), lastAttemptTime+200*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	err := policy.waitFor(ctx, func() (bool, error) {
+		calls++
+		return false, nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) || calls < 3 {
+		t.Errorf("waitFor() = %v after %d calls, want context.DeadlineExceeded after more than the max attempts", err, calls)
+	}
+}
+
+func TestSleepUntilDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), lastAttemptTime+100*time.Millisecond)
+	defer cancel()
+
+	// The first sleep is cut short to leave time for a last attempt before the
+	// deadline, and the next one gives up.
+	start := time.Now()
+	if err := sleep(ctx, time.Hour); err != nil || time.Since(start) > time.Second {
+		t.Errorf("sleep() = %v after %s, want nil before the deadline", err, time.Since(start))
+	}
+	if err := sleep(ctx, time.Hour); !errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
+		t.Errorf("sleep() = %v, want context.DeadlineExceeded before the deadline", err)
+	}
+}
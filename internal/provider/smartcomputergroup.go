@@ -1,15 +1,25 @@
 package provider
 
 import (
+	"context"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jc0b/go-jamfpro-api/jamfpro"
+	"sort"
 )
 
 type smartcomputergroup struct {
-	Id       types.Int64  `tfsdk:"id"`
-	Name     types.String `tfsdk:"name"`
-	Criteria types.Set    `tfsdk:"criteria"`
+	Id               types.Int64  `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Criteria         types.List   `tfsdk:"criteria"`
+	Computers        types.Set    `tfsdk:"computers"`
+	IncludeComputers types.Bool   `tfsdk:"include_computers"`
+	Timeouts         types.Object `tfsdk:"timeouts"`
 }
 
 var criteriaAttrTypes = map[string]attr.Type{
@@ -22,9 +32,11 @@ var criteriaAttrTypes = map[string]attr.Type{
 	"closing_paren": types.BoolType,
 }
 
+// smartComputerGroupForState converts a smart computer group to its model, with
+// the criteria in the order Jamf Pro evaluates them.
 func smartComputerGroupForState(c *jamfpro.ComputerGroup) smartcomputergroup {
 	criteria := make([]attr.Value, 0)
-	for _, criterion := range c.Criteria {
+	for _, criterion := range criteriaByPriority(c.Criteria) {
 		criteria = append(
 			criteria,
 			types.ObjectValueMust(
@@ -42,22 +54,44 @@ func smartComputerGroupForState(c *jamfpro.ComputerGroup) smartcomputergroup {
 		)
 	}
 	return smartcomputergroup{
-		Id:       types.Int64Value(int64(c.Id)),
-		Name:     types.StringValue(c.Name),
-		Criteria: types.SetValueMust(types.ObjectType{AttrTypes: criteriaAttrTypes}, criteria),
+		Id:               types.Int64Value(int64(c.Id)),
+		Name:             types.StringValue(c.Name),
+		Criteria:         types.ListValueMust(types.ObjectType{AttrTypes: criteriaAttrTypes}, criteria),
+		Computers:        computersForState(c.Computers),
+		IncludeComputers: types.BoolNull(),
+		Timeouts:         types.ObjectNull(timeoutsAttrTypes),
+	}
+}
+
+// withSettingsOf returns the state with the settings of the provider kept from
+// data, the plan or prior state. The computers are left out if
+// include_computers is false.
+func (s smartcomputergroup) withSettingsOf(data smartcomputergroup) smartcomputergroup {
+	s.Timeouts = data.Timeouts
+	s.IncludeComputers = data.IncludeComputers
+	if !data.IncludeComputers.IsNull() && !data.IncludeComputers.ValueBool() {
+		s.Computers = types.SetNull(types.ObjectType{AttrTypes: computerAttrTypes})
 	}
+	return s
 }
 
+// smartComputerGroupRequestWithState converts the model of a smart computer
+// group to a request. The criteria are sent in the order of the list, and
+// those without a priority get their position in it.
 func smartComputerGroupRequestWithState(data smartcomputergroup) *jamfpro.ComputerGroupRequest {
 	criteria := make([]jamfpro.ComputerGroupCriteria, 0)
-	for _, criterion := range data.Criteria.Elements() {
+	for i, criterion := range data.Criteria.Elements() {
 		criterionMap := criterion.(types.Object).Attributes()
 		if criterionMap != nil {
+			priority := criterionMap["priority"].(types.Int64)
+			if priority.IsNull() || priority.IsUnknown() {
+				priority = types.Int64Value(int64(i))
+			}
 			criteria = append(
 				criteria,
 				jamfpro.ComputerGroupCriteria{
 					Name:         criterionMap["name"].(types.String).ValueString(),
-					Priority:     int(criterionMap["priority"].(types.Int64).ValueInt64()),
+					Priority:     int(priority.ValueInt64()),
 					AndOr:        criterionMap["and_or"].(types.String).ValueString(),
 					SearchType:   criterionMap["search_type"].(types.String).ValueString(),
 					Value:        criterionMap["value"].(types.String).ValueString(),
@@ -68,6 +102,96 @@ func smartComputerGroupRequestWithState(data smartcomputergroup) *jamfpro.Comput
 	}
 	return &jamfpro.ComputerGroupRequest{
 		Name:     data.Name.ValueString(),
+		IsSmart:  true,
 		Criteria: criteria,
 	}
 }
+
+// criteriaByPriority returns a copy of criteria in the order Jamf Pro
+// evaluates them, keeping the order of criteria with the same priority.
+func criteriaByPriority(criteria []jamfpro.ComputerGroupCriteria) []jamfpro.ComputerGroupCriteria {
+	sorted := append([]jamfpro.ComputerGroupCriteria(nil), criteria...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority < sorted[j].Priority
+	})
+	return sorted
+}
+
+// criteriaPriorityFromPosition plans the priority of criteria without a
+// configured priority as their position in the list of criteria.
+func criteriaPriorityFromPosition() planmodifier.Int64 {
+	return criteriaPriorityFromPositionModifier{}
+}
+
+type criteriaPriorityFromPositionModifier struct{}
+
+func (m criteriaPriorityFromPositionModifier) Description(ctx context.Context) string {
+	return "Defaults to the position of the criteria in the list."
+}
+
+func (m criteriaPriorityFromPositionModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m criteriaPriorityFromPositionModifier) PlanModifyInt64(ctx context.Context, request planmodifier.Int64Request, response *planmodifier.Int64Response) {
+	if !request.ConfigValue.IsNull() {
+		return
+	}
+	step, _ := request.Path.ParentPath().Steps().LastStep()
+	if position, ok := step.(path.PathStepElementKeyInt); ok {
+		response.PlanValue = types.Int64Value(int64(position))
+	}
+}
+
+// smartcomputergroupV0 is the model of version 0 of the smart computer group
+// schema, which kept the criteria in a set.
+type smartcomputergroupV0 struct {
+	Id       types.Int64  `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Criteria types.Set    `tfsdk:"criteria"`
+	Timeouts types.Object `tfsdk:"timeouts"`
+}
+
+// upgradeSmartComputerGroupStateV0 moves the criteria of a version 0 state to
+// a list, ordered by their priority.
+func upgradeSmartComputerGroupStateV0(ctx context.Context, request resource.UpgradeStateRequest, response *resource.UpgradeStateResponse) {
+	var prior smartcomputergroupV0
+	response.Diagnostics.Append(request.State.Get(ctx, &prior)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	criteria := prior.Criteria.Elements()
+	sort.SliceStable(criteria, func(i, j int) bool {
+		a := criteria[i].(types.Object).Attributes()["priority"].(types.Int64)
+		b := criteria[j].(types.Object).Attributes()["priority"].(types.Int64)
+		return !a.IsNull() && (b.IsNull() || a.ValueInt64() < b.ValueInt64())
+	})
+	upgraded, diags := types.ListValue(types.ObjectType{AttrTypes: criteriaAttrTypes}, criteria)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, smartcomputergroup{
+		Id:               prior.Id,
+		Name:             prior.Name,
+		Criteria:         upgraded,
+		Computers:        types.SetNull(types.ObjectType{AttrTypes: computerAttrTypes}),
+		IncludeComputers: types.BoolNull(),
+		Timeouts:         prior.Timeouts,
+	})...)
+}
+
+// moveComputerGroupState sets the state of a smart group moved from a static
+// group. It has no criteria, so the next apply sets those configured.
+func moveComputerGroupState(ctx context.Context, moved movedComputerGroup, state *tfsdk.State) diag.Diagnostics {
+	return state.Set(ctx, smartcomputergroup{
+		Id:               moved.Id,
+		Name:             moved.Name,
+		Criteria:         types.ListValueMust(types.ObjectType{AttrTypes: criteriaAttrTypes}, []attr.Value{}),
+		Computers:        types.SetNull(types.ObjectType{AttrTypes: computerAttrTypes}),
+		IncludeComputers: types.BoolNull(),
+		Timeouts:         moved.Timeouts,
+	})
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"time"
+)
+
+// defaultTimeouts are the durations of the operations of a resource with a
+// `timeouts` block, when none is configured.
+var defaultTimeouts = map[string]time.Duration{
+	"create": 10 * time.Minute,
+	"read":   5 * time.Minute,
+	"update": 10 * time.Minute,
+	"delete": 5 * time.Minute,
+}
+
+var timeoutsAttrTypes = map[string]attr.Type{
+	"create": types.StringType,
+	"read":   types.StringType,
+	"update": types.StringType,
+	"delete": types.StringType,
+}
+
+// timeoutsBlock returns the schema of a `timeouts` block, which bounds the
+// duration of the create, read, update and delete operations of a resource.
+func timeoutsBlock() schema.Block {
+	attributes := make(map[string]schema.Attribute, len(defaultTimeouts))
+	for operation, timeout := range defaultTimeouts {
+		attributes[operation] = schema.StringAttribute{
+			Optional: true,
+			Description: fmt.Sprintf("Maximum duration of the %s operation, as a duration string (e.g. 30s or 2h45m). "+
+				"Defaults to %s.", operation, timeout),
+			MarkdownDescription: fmt.Sprintf("Maximum duration of the %s operation, as a duration string (e.g. `30s` or "+
+				"`2h45m`). Defaults to `%s`.", operation, timeout),
+			Validators: []validator.String{
+				durationValidator{},
+			},
+		}
+	}
+	return schema.SingleNestedBlock{
+		Description: "Maximum durations of the operations on the resource.",
+		Attributes:  attributes,
+	}
+}
+
+// timeoutFor returns the configured duration of an operation, or its default.
+func timeoutFor(timeouts types.Object, operation string) (time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if timeouts.IsNull() || timeouts.IsUnknown() {
+		return defaultTimeouts[operation], diags
+	}
+	value, ok := timeouts.Attributes()[operation].(types.String)
+	if !ok || value.IsNull() || value.IsUnknown() {
+		return defaultTimeouts[operation], diags
+	}
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Invalid timeout",
+			fmt.Sprintf("Unable to parse the %s timeout %q: %s", operation, value.ValueString(), err))
+	}
+	return timeout, diags
+}
+
+// durationValidator checks that a string is a positive duration.
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration such as 30s or 2h45m"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a positive duration such as `30s` or `2h45m`"
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+	if d, err := time.ParseDuration(request.ConfigValue.ValueString()); err != nil || d <= 0 {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid duration",
+			fmt.Sprintf("Attribute %s %s, got: %q", request.Path, v.Description(ctx), request.ConfigValue.ValueString()),
+		)
+	}
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTimeoutFor(t *testing.T) {
+	timeouts := types.ObjectValueMust(timeoutsAttrTypes, map[string]attr.Value{
+		"create": types.StringValue("90s"),
+		"read":   types.StringNull(),
+		"update": types.StringValue("1h"),
+		"delete": types.StringNull(),
+	})
+
+	for operation, want := range map[string]time.Duration{
+		"create": 90 * time.Second,
+		"read":   defaultTimeouts["read"],
+		"update": time.Hour,
+		"delete": defaultTimeouts["delete"],
+	} {
+		got, diags := timeoutFor(timeouts, operation)
+		if diags.HasError() || got != want {
+			t.Errorf("timeoutFor(%s) = %s, %v, want %s", operation, got, diags, want)
+		}
+	}
+
+	if got, _ := timeoutFor(types.ObjectNull(timeoutsAttrTypes), "create"); got != defaultTimeouts["create"] {
+		t.Errorf("timeoutFor(null) = %s, want the default", got)
+	}
+}
+
+func TestDurationValidator(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"30s":    true,
+		"2h45m":  true,
+		"0s":     false,
+		"-1m":    false,
+		"10":     false,
+		"1 hour": false,
+	} {
+		request := validator.StringRequest{Path: path.Root("timeouts").AtName("create"), ConfigValue: types.StringValue(value)}
+		response := &validator.StringResponse{}
+		durationValidator{}.ValidateString(context.Background(), request, response)
+		if response.Diagnostics.HasError() == valid {
+			t.Errorf("%q: valid = %t, want %t", value, !response.Diagnostics.HasError(), valid)
+		}
+	}
+}
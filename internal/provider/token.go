@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenRefreshMargin is how long before its expiry a session token is replaced.
+// Tokens with a short lifetime are replaced after three quarters of it instead.
+const tokenRefreshMargin = 5 * time.Minute
+
+// sessionToken is a Jamf Pro bearer token and the time it expires.
+type sessionToken struct {
+	Token   string    `json:"token"`
+	Issued  time.Time `json:"issued"`
+	Expires time.Time `json:"expires"`
+}
+
+// valid reports whether the token can be used without being refreshed first.
+func (t sessionToken) valid() bool {
+	margin := min(tokenRefreshMargin, t.Expires.Sub(t.Issued)/4)
+	return t.Token != "" && time.Now().Add(margin).Before(t.Expires)
+}
+
+// normalizeInstanceURL returns the base URL of a Jamf Pro instance given as a
+// URL or a bare host name (e.g. myinstance.jamfcloud.com).
+func normalizeInstanceURL(instanceURL string) string {
+	if !strings.Contains(instanceURL, "://") {
+		instanceURL = "https://" + instanceURL
+	}
+	return strings.TrimRight(instanceURL, "/")
+}
+
+// fetchOAuthToken requests a session token for an API client using the client credentials grant.
+func fetchOAuthToken(ctx context.Context, httpClient *http.Client, instanceURL, clientId, clientSecret string) (sessionToken, error) {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {clientId},
+		"client_secret": {clientSecret},
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, normalizeInstanceURL(instanceURL)+"/api/oauth/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return sessionToken{}, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return sessionToken{}, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return sessionToken{}, fmt.Errorf("token request failed with status %d: %s", response.StatusCode, body)
+	}
+
+	var oauthResponse struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&oauthResponse); err != nil {
+		return sessionToken{}, fmt.Errorf("unable to decode token response: %w", err)
+	}
+	if oauthResponse.AccessToken == "" {
+		return sessionToken{}, fmt.Errorf("token response did not contain an access token")
+	}
+
+	now := time.Now()
+	return sessionToken{
+		Token:   oauthResponse.AccessToken,
+		Issued:  now,
+		Expires: now.Add(time.Duration(oauthResponse.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// fetchBasicToken requests a session token for a user account using basic authentication.
+func fetchBasicToken(ctx context.Context, httpClient *http.Client, instanceURL, username, password string) (sessionToken, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, normalizeInstanceURL(instanceURL)+"/api/v1/auth/token", nil)
+	if err != nil {
+		return sessionToken{}, err
+	}
+	request.SetBasicAuth(username, password)
+
+	return doAuthTokenRequest(httpClient, request)
+}
+
+// keepAliveToken exchanges a session token of a user account for a new one.
+// The previous token is invalidated by the server.
+func keepAliveToken(ctx context.Context, httpClient *http.Client, instanceURL string, token sessionToken) (sessionToken, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, normalizeInstanceURL(instanceURL)+"/api/v1/auth/keep-alive", nil)
+	if err != nil {
+		return sessionToken{}, err
+	}
+	request.Header.Set("Authorization", "Bearer "+token.Token)
+
+	return doAuthTokenRequest(httpClient, request)
+}
+
+// invalidateToken revokes a session token of a user account.
+func invalidateToken(ctx context.Context, httpClient *http.Client, instanceURL string, token sessionToken) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, normalizeInstanceURL(instanceURL)+"/api/v1/auth/invalidate-token", nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", "Bearer "+token.Token)
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer drainAndClose(response)
+
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK {
+		return fmt.Errorf("token invalidation failed with status %d", response.StatusCode)
+	}
+	return nil
+}
+
+// doAuthTokenRequest sends a request to one of the /api/v1/auth endpoints returning a token.
+func doAuthTokenRequest(httpClient *http.Client, request *http.Request) (sessionToken, error) {
+	request.Header.Set("Accept", "application/json")
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return sessionToken{}, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return sessionToken{}, fmt.Errorf("token request failed with status %d: %s", response.StatusCode, body)
+	}
+
+	var authResponse struct {
+		Token   string    `json:"token"`
+		Expires time.Time `json:"expires"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&authResponse); err != nil {
+		return sessionToken{}, fmt.Errorf("unable to decode token response: %w", err)
+	}
+	if authResponse.Token == "" {
+		return sessionToken{}, fmt.Errorf("token response did not contain a token")
+	}
+
+	return sessionToken{
+		Token:   authResponse.Token,
+		Issued:  time.Now(),
+		Expires: authResponse.Expires,
+	}, nil
+}
+
+// tokenSource hands out a valid session token, requesting a new one when the
+// current one is about to expire or was rejected. It is safe for concurrent use.
+type tokenSource struct {
+	mu    sync.Mutex
+	token sessionToken
+
+	// fetch requests a new session token.
+	fetch func(ctx context.Context) (sessionToken, error)
+	// keepAlive, if set, exchanges a token that is about to expire for a new one.
+	keepAlive func(ctx context.Context, token sessionToken) (sessionToken, error)
+	// invalidate, if set, revokes a token that is replaced before it expires.
+	invalidate func(ctx context.Context, token sessionToken)
+	// refreshed, if set, is called with every new token.
+	refreshed func(ctx context.Context, token sessionToken)
+}
+
+// Token returns the current session token, refreshing it first if needed.
+func (s *tokenSource) Token(ctx context.Context) (sessionToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.valid() {
+		return s.token, nil
+	}
+
+	previous := s.token
+	live := previous.Token != "" && time.Now().Before(previous.Expires)
+	if live && s.keepAlive != nil {
+		if token, err := s.keepAlive(ctx, previous); err == nil {
+			s.replace(ctx, token)
+			return token, nil
+		}
+	}
+
+	token, err := s.fetch(ctx)
+	if err != nil {
+		return sessionToken{}, fmt.Errorf("unable to refresh session token: %w", err)
+	}
+	if live && s.invalidate != nil {
+		s.invalidate(ctx, previous)
+	}
+	s.replace(ctx, token)
+	return token, nil
+}
+
+func (s *tokenSource) replace(ctx context.Context, token sessionToken) {
+	s.token = token
+	if s.refreshed != nil {
+		s.refreshed(ctx, token)
+	}
+}
+
+// Invalidate discards token if it is still the current one, so that the next
+// call to Token requests a new one. Concurrent callers that were rejected with
+// the same token therefore only cause a single refresh.
+func (s *tokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.Token == token {
+		s.token = sessionToken{}
+	}
+}
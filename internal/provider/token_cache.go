@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// tokenCache stores session tokens on disk, in one file per instance URL and
+// principal (the client ID or user account), so that consecutive Terraform
+// runs can reuse them.
+// The zero value is a disabled cache.
+type tokenCache struct {
+	dir string
+}
+
+// defaultTokenCacheDir returns the directory used when none is configured.
+func defaultTokenCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "terraform-provider-jamfpro")
+}
+
+func (c tokenCache) enabled() bool {
+	return c.dir != ""
+}
+
+func (c tokenCache) path(instanceURL, principal string) string {
+	sum := sha256.Sum256([]byte(normalizeInstanceURL(instanceURL) + "\n" + principal))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// load returns the cached token for an instance URL and principal if it has not expired.
+func (c tokenCache) load(instanceURL, principal string) (sessionToken, bool) {
+	if !c.enabled() {
+		return sessionToken{}, false
+	}
+	content, err := os.ReadFile(c.path(instanceURL, principal))
+	if err != nil {
+		return sessionToken{}, false
+	}
+	var token sessionToken
+	if err := json.Unmarshal(content, &token); err != nil || !time.Now().Before(token.Expires) {
+		return sessionToken{}, false
+	}
+	return token, true
+}
+
+// store saves the token for an instance URL and principal, readable only by the current user.
+func (c tokenCache) store(instanceURL, principal string, token sessionToken) error {
+	if !c.enabled() {
+		return nil
+	}
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return err
+	}
+	content, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that a concurrent run never reads a partial token.
+	f, err := os.CreateTemp(c.dir, ".token-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := f.Chmod(0600); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), c.path(instanceURL, principal))
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jc0b/terraform-provider-jamfpro/internal/jamfmock"
+)
+
+func TestTokenCache(t *testing.T) {
+	cache := tokenCache{dir: t.TempDir()}
+	token := sessionToken{Token: "abc", Issued: time.Now(), Expires: time.Now().Add(time.Hour)}
+
+	if err := cache.store("https://one.jamfcloud.com", "client", token); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(cache.path("https://one.jamfcloud.com", "client"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("cache file mode = %o, want 600", mode)
+	}
+
+	if cached, ok := cache.load("one.jamfcloud.com/", "client"); !ok || cached.Token != "abc" {
+		t.Errorf("load() = %v, %t, want cached token", cached, ok)
+	}
+	if _, ok := cache.load("https://one.jamfcloud.com", "other-client"); ok {
+		t.Error("token loaded for another client")
+	}
+	if _, ok := cache.load("https://two.jamfcloud.com", "client"); ok {
+		t.Error("token loaded for another instance")
+	}
+}
+
+func TestTokenCacheExpiry(t *testing.T) {
+	cache := tokenCache{dir: t.TempDir()}
+	token := sessionToken{Token: "abc", Issued: time.Now().Add(-time.Hour), Expires: time.Now().Add(-time.Minute)}
+
+	if err := cache.store("https://one.jamfcloud.com", "client", token); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.load("https://one.jamfcloud.com", "client"); ok {
+		t.Error("expired token was loaded")
+	}
+}
+
+func TestTokenCacheDisabled(t *testing.T) {
+	var cache tokenCache
+	token := sessionToken{Token: "abc", Issued: time.Now(), Expires: time.Now().Add(time.Hour)}
+
+	if err := cache.store("https://one.jamfcloud.com", "client", token); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.load("https://one.jamfcloud.com", "client"); ok {
+		t.Error("disabled cache returned a token")
+	}
+}
+
+func TestFetchOAuthToken(t *testing.T) {
+	server := jamfmock.NewServer()
+	defer server.Close()
+
+	token, err := fetchOAuthToken(context.Background(), http.DefaultClient, server.URL, jamfmock.ClientID, jamfmock.ClientSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !token.valid() {
+		t.Errorf("token %v is not valid", token)
+	}
+	if remaining := time.Until(token.Expires); remaining > 20*time.Minute || remaining < 19*time.Minute {
+		t.Errorf("token expires in %s, want the lifetime returned by the server", remaining)
+	}
+
+	if _, err := fetchOAuthToken(context.Background(), http.DefaultClient, server.URL, jamfmock.ClientID, "wrong"); err == nil {
+		t.Error("token fetched with invalid credentials")
+	}
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jc0b/terraform-provider-jamfpro/internal/jamfmock"
+)
+
+func TestFetchBasicToken(t *testing.T) {
+	server := jamfmock.NewServer()
+	defer server.Close()
+
+	token, err := fetchBasicToken(context.Background(), http.DefaultClient, server.URL, jamfmock.Username, jamfmock.Password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !token.valid() {
+		t.Errorf("token %v is not valid", token)
+	}
+
+	if _, err := fetchBasicToken(context.Background(), http.DefaultClient, server.URL, jamfmock.Username, "wrong"); err == nil {
+		t.Error("token fetched with invalid credentials")
+	}
+}
+
+func TestKeepAliveToken(t *testing.T) {
+	server := jamfmock.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	token, err := fetchBasicToken(ctx, http.DefaultClient, server.URL, jamfmock.Username, jamfmock.Password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	renewed, err := keepAliveToken(ctx, http.DefaultClient, server.URL, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renewed.Token == token.Token {
+		t.Error("keep-alive returned the same token")
+	}
+	if _, err := keepAliveToken(ctx, http.DefaultClient, server.URL, token); err == nil {
+		t.Error("previous token still accepted after keep-alive")
+	}
+
+	if err := invalidateToken(ctx, http.DefaultClient, server.URL, renewed); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keepAliveToken(ctx, http.DefaultClient, server.URL, renewed); err == nil {
+		t.Error("token still accepted after invalidation")
+	}
+}
+
+func TestTokenSourceKeepAlive(t *testing.T) {
+	expiring := sessionToken{Token: "expiring", Issued: time.Now().Add(-time.Hour), Expires: time.Now().Add(time.Minute)}
+	var kept, fetched, invalidated []string
+
+	tokens := &tokenSource{
+		token: expiring,
+		fetch: func(ctx context.Context) (sessionToken, error) {
+			fetched = append(fetched, "new")
+			return sessionToken{Token: "new", Issued: time.Now(), Expires: time.Now().Add(time.Hour)}, nil
+		},
+		keepAlive: func(ctx context.Context, token sessionToken) (sessionToken, error) {
+			kept = append(kept, token.Token)
+			return sessionToken{Token: "kept", Issued: time.Now(), Expires: time.Now().Add(time.Hour)}, nil
+		},
+		invalidate: func(ctx context.Context, token sessionToken) {
+			invalidated = append(invalidated, token.Token)
+		},
+	}
+
+	token, err := tokens.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Token != "kept" || len(kept) != 1 || len(fetched) != 0 {
+		t.Errorf("Token() = %q after %d keep-alives and %d fetches, want a single keep-alive", token.Token, len(kept), len(fetched))
+	}
+
+	// When the keep-alive fails, a new token is fetched and the old one invalidated.
+	tokens.token = expiring
+	tokens.keepAlive = func(ctx context.Context, token sessionToken) (sessionToken, error) {
+		return sessionToken{}, errors.New("keep-alive failed")
+	}
+	token, err = tokens.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Token != "new" || len(invalidated) != 1 || invalidated[0] != "expiring" {
+		t.Errorf("Token() = %q, invalidated %v, want a new token and the expiring one invalidated", token.Token, invalidated)
+	}
+}
@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/jc0b/go-jamfpro-api/jamfpro"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultTransport is the transport the provider's own round trippers send
+// requests with. It is captured before http.DefaultTransport is replaced by
+// useTransport.
+var defaultTransport = http.DefaultTransport
+
+// tokenPaths are the authentication endpoints, which are called with their own
+// credentials rather than a session token.
+var tokenPaths = map[string]bool{
+	"/api/oauth/token":              true,
+	"/api/v1/auth/token":            true,
+	"/api/v1/auth/keep-alive":       true,
+	"/api/v1/auth/invalidate-token": true,
+}
+
+// clientTransportHeader tags the requests of a jamfpro.Client with the
+// transport of the provider configuration that created it.
+const clientTransportHeader = "X-Jamfpro-Provider-Transport"
+
+// clientTransports routes the requests sent through http.DefaultTransport to
+// the transport their clientTransportHeader refers to. Requests without the
+// header, from other clients in the process, are sent with the transport that
+// was the default before.
+type clientTransports struct {
+	base http.RoundTripper
+
+	mu         sync.RWMutex
+	transports map[string]http.RoundTripper
+	next       int
+}
+
+var (
+	installClientTransports sync.Once
+	routedTransports        = &clientTransports{base: defaultTransport, transports: map[string]http.RoundTripper{}}
+)
+
+// useTransport makes rt the transport of the requests of c. jamfpro.Client
+// sends its requests through http.DefaultTransport and offers no way to supply
+// another transport, but sends its ExtraHeader with every request. So the
+// default transport is replaced, once, by one routing the requests of each
+// client to its own transport by a header, and leaving other requests as they
+// were. Every provider configuration thereby keeps its own authentication,
+// retries and request limits, however many share the process.
+func useTransport(c *jamfpro.Client, rt http.RoundTripper) {
+	installClientTransports.Do(func() {
+		http.DefaultTransport = routedTransports
+	})
+	c.ExtraHeader[clientTransportHeader] = routedTransports.add(rt)
+}
+
+// add registers rt, and returns the value of clientTransportHeader routing
+// requests to it.
+func (t *clientTransports) add(rt http.RoundTripper) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.next++
+	key := strconv.Itoa(t.next)
+	t.transports[key] = rt
+	return key
+}
+
+func (t *clientTransports) RoundTrip(request *http.Request) (*http.Response, error) {
+	key := request.Header.Get(clientTransportHeader)
+	if key == "" {
+		return t.base.RoundTrip(request)
+	}
+	t.mu.RLock()
+	rt, ok := t.transports[key]
+	t.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no transport registered for %s %q", clientTransportHeader, key)
+	}
+
+	routed := request.Clone(request.Context())
+	routed.Header.Del(clientTransportHeader)
+	return rt.RoundTrip(routed)
+}
+
+// transportConfig configures how the provider connects to a Jamf Pro instance.
+type transportConfig struct {
+	// caCertificatePEM holds additional CA certificates to trust.
+	caCertificatePEM   string
+	proxyURL           string
+	insecureSkipVerify bool
+	// requestTimeout bounds the duration of each request, reading the body included. Zero means no limit.
+	requestTimeout time.Duration
+}
+
+// newBaseTransport returns the transport requests are ultimately sent with.
+func newBaseTransport(config transportConfig) (http.RoundTripper, error) {
+	var transport *http.Transport
+	if t, ok := defaultTransport.(*http.Transport); ok {
+		transport = t.Clone()
+	} else {
+		transport = &http.Transport{Proxy: http.ProxyFromEnvironment}
+	}
+
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.insecureSkipVerify,
+	}
+	if config.caCertificatePEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(config.caCertificatePEM)) {
+			return nil, fmt.Errorf("no valid PEM encoded certificate found in the CA certificate")
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	if config.proxyURL != "" {
+		proxyURL, err := url.Parse(config.proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		if proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q: a scheme and host are required", config.proxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if config.requestTimeout > 0 {
+		return &timeoutTransport{base: transport, timeout: config.requestTimeout}, nil
+	}
+	return transport, nil
+}
+
+// timeoutTransport cancels requests that do not complete within a timeout.
+type timeoutTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(request.Context(), t.timeout)
+	response, err := t.base.RoundTrip(request.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	response.Body = &onCloseBody{ReadCloser: response.Body, onClose: cancel}
+	return response, nil
+}
+
+// onCloseBody calls onClose once the response body is closed, to release what
+// the request held on to until then.
+type onCloseBody struct {
+	io.ReadCloser
+	onClose func()
+}
+
+func (b *onCloseBody) Close() error {
+	defer b.onClose()
+	return b.ReadCloser.Close()
+}
+
+// authTransport sets the session token on requests to a Jamf Pro instance.
+// When a token is rejected, a new one is requested and the request is retried once.
+type authTransport struct {
+	base   http.RoundTripper
+	host   string
+	tokens *tokenSource
+}
+
+func newAuthTransport(base http.RoundTripper, instanceURL string, tokens *tokenSource) (*authTransport, error) {
+	u, err := url.Parse(normalizeInstanceURL(instanceURL))
+	if err != nil {
+		return nil, err
+	}
+	return &authTransport{base: base, host: u.Host, tokens: tokens}, nil
+}
+
+func (t *authTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.URL.Host != t.host || tokenPaths[request.URL.Path] {
+		return t.base.RoundTrip(request)
+	}
+
+	token, err := t.tokens.Token(request.Context())
+	if err != nil {
+		return nil, err
+	}
+	response, err := t.base.RoundTrip(withBearerToken(request, token.Token))
+	if err != nil || response.StatusCode != http.StatusUnauthorized {
+		return response, err
+	}
+
+	// The token was revoked or expired early. A request with a body can only
+	// be sent again if the body can be recreated.
+	if request.Body != nil && request.Body != http.NoBody && request.GetBody == nil {
+		return response, nil
+	}
+	retry, err := rewindRequest(request)
+	if err != nil {
+		return response, nil
+	}
+	// The rejected response is closed before a new token is requested, as it
+	// holds on to its request slot until then, which the token request or
+	// other requests refreshing the token may be waiting for.
+	drainAndClose(response)
+	t.tokens.Invalidate(token.Token)
+	token, err = t.tokens.Token(request.Context())
+	if err != nil {
+		return nil, fmt.Errorf("session token rejected, and unable to obtain a new one: %w", err)
+	}
+
+	return t.base.RoundTrip(withBearerToken(retry, token.Token))
+}
+
+// withBearerToken returns a copy of request authenticated with token.
+func withBearerToken(request *http.Request, token string) *http.Request {
+	authenticated := request.Clone(request.Context())
+	authenticated.Header.Set("Authorization", "Bearer "+token)
+	return authenticated
+}
+
+// rewindRequest returns a copy of request that can be sent again.
+func rewindRequest(request *http.Request) (*http.Request, error) {
+	rewound := request.Clone(request.Context())
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		rewound.Body = body
+	}
+	return rewound, nil
+}
+
+// drainAndClose discards the rest of a response body so that its connection can be reused.
+func drainAndClose(response *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+	response.Body.Close()
+}
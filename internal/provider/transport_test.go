@@ -0,0 +1,301 @@
+package provider
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jc0b/terraform-provider-jamfpro/internal/jamfmock"
+)
+
+func testAuthClient(t *testing.T, server *jamfmock.Server) *http.Client {
+	t.Helper()
+	tokens := &tokenSource{
+		fetch: func(ctx context.Context) (sessionToken, error) {
+			return fetchOAuthToken(ctx, http.DefaultClient, server.URL, jamfmock.ClientID, jamfmock.ClientSecret)
+		},
+	}
+	transport, err := newAuthTransport(defaultTransport, server.URL, tokens)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &http.Client{Transport: transport}
+}
+
+func countRequests(server *jamfmock.Server, path string) int {
+	count := 0
+	for _, r := range server.Requests() {
+		if r.Path == path {
+			count++
+		}
+	}
+	return count
+}
+
+func TestAuthTransportRefreshesRejectedToken(t *testing.T) {
+	server := jamfmock.NewServer()
+	defer server.Close()
+	client := testAuthClient(t, server)
+
+	for i := 0; i < 2; i++ {
+		response, err := client.Get(server.URL + "/api/v1/categories")
+		if err != nil {
+			t.Fatal(err)
+		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			t.Fatalf("request %d returned %d", i, response.StatusCode)
+		}
+		server.RevokeTokens()
+	}
+
+	if got := countRequests(server, "/api/oauth/token"); got != 2 {
+		t.Errorf("%d token requests, want 2", got)
+	}
+}
+
+func TestAuthTransportConcurrentRefresh(t *testing.T) {
+	server := jamfmock.NewServer()
+	defer server.Close()
+	client := testAuthClient(t, server)
+
+	response, err := client.Get(server.URL + "/api/v1/departments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	server.RevokeTokens()
+
+	var wg sync.WaitGroup
+	statuses := make(chan int, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := client.Get(server.URL + "/api/v1/departments")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			response.Body.Close()
+			statuses <- response.StatusCode
+		}()
+	}
+	wg.Wait()
+	close(statuses)
+
+	for status := range statuses {
+		if status != http.StatusOK {
+			t.Errorf("request returned %d", status)
+		}
+	}
+	if got := countRequests(server, "/api/oauth/token"); got != 2 {
+		t.Errorf("%d token requests, want 2", got)
+	}
+}
+
+func TestAuthTransportRefreshWithinLimit(t *testing.T) {
+	server := jamfmock.NewServer()
+	defer server.Close()
+
+	// The token requests share the limit of a single request in flight, so
+	// that a rejected response holding on to its slot would never let them
+	// through.
+	limited := &http.Client{Transport: newLimitTransport(defaultTransport, newRequestLimiter(1, 0))}
+	tokens := &tokenSource{
+		fetch: func(ctx context.Context) (sessionToken, error) {
+			return fetchOAuthToken(ctx, limited, server.URL, jamfmock.ClientID, jamfmock.ClientSecret)
+		},
+	}
+	transport, err := newAuthTransport(limited.Transport, server.URL, tokens)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: transport}
+
+	response, err := client.Get(server.URL + "/api/v1/departments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	server.RevokeTokens()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			request, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/v1/departments", nil)
+			response, err := client.Do(request)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			response.Body.Close()
+			if response.StatusCode != http.StatusOK {
+				t.Errorf("request returned %d", response.StatusCode)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestAuthTransportRetriesRequestBody(t *testing.T) {
+	server := jamfmock.NewServer()
+	defer server.Close()
+	client := testAuthClient(t, server)
+
+	response, err := client.Get(server.URL + "/api/v1/buildings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	server.RevokeTokens()
+
+	response, err = client.Post(server.URL+"/api/v1/buildings", "application/json", strings.NewReader(`{"name": "HQ"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		t.Errorf("create returned %d", response.StatusCode)
+	}
+}
+
+func certificatePEM(server *jamfmock.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+func TestBaseTransportCACertificate(t *testing.T) {
+	server := jamfmock.NewTLSServer()
+	defer server.Close()
+
+	for name, test := range map[string]struct {
+		config  transportConfig
+		wantErr bool
+	}{
+		"system CAs":             {config: transportConfig{}, wantErr: true},
+		"custom CA":              {config: transportConfig{caCertificatePEM: certificatePEM(server)}},
+		"custom CA with timeout": {config: transportConfig{caCertificatePEM: certificatePEM(server), requestTimeout: time.Minute}},
+		"skip verify":            {config: transportConfig{insecureSkipVerify: true}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			transport, err := newBaseTransport(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = fetchOAuthToken(context.Background(), &http.Client{Transport: transport}, server.URL, jamfmock.ClientID, jamfmock.ClientSecret)
+			if (err != nil) != test.wantErr {
+				t.Errorf("fetchOAuthToken() error = %v, wantErr %t", err, test.wantErr)
+			}
+		})
+	}
+
+	if _, err := newBaseTransport(transportConfig{caCertificatePEM: "not a certificate"}); err == nil {
+		t.Error("invalid CA certificate accepted")
+	}
+}
+
+func TestBaseTransportProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer proxy.Close()
+
+	transport, err := newBaseTransport(transportConfig{proxyURL: proxy.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	response, err := (&http.Client{Transport: transport}).Get("http://jamf.example.invalid/api/v1/categories")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusTeapot || len(proxied) != 1 || proxied[0] != "http://jamf.example.invalid/api/v1/categories" {
+		t.Errorf("request was not sent through the proxy: status %d, proxied %v", response.StatusCode, proxied)
+	}
+
+	if _, err := newBaseTransport(transportConfig{proxyURL: "proxy.example.com"}); err == nil {
+		t.Error("proxy URL without scheme accepted")
+	}
+}
+
+func TestBaseTransportRequestTimeout(t *testing.T) {
+	server := jamfmock.NewServer()
+	defer server.Close()
+	server.InjectFault(jamfmock.Fault{Path: "/api/oauth/token", Status: http.StatusServiceUnavailable, Delay: time.Second})
+
+	transport, err := newBaseTransport(transportConfig{requestTimeout: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: transport}
+
+	if _, err := fetchOAuthToken(context.Background(), client, server.URL, jamfmock.ClientID, jamfmock.ClientSecret); err == nil {
+		t.Error("slow request did not time out")
+	}
+	if _, err := fetchOAuthToken(context.Background(), client, server.URL, jamfmock.ClientID, jamfmock.ClientSecret); err != nil {
+		t.Errorf("request after timeout failed: %s", err)
+	}
+}
+
+// countingTransport counts the requests sent through it.
+type countingTransport struct {
+	base     http.RoundTripper
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	t.requests++
+	return t.base.RoundTrip(request)
+}
+
+func TestClientTransportsRoute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(clientTransportHeader) != "" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	base := &countingTransport{base: defaultTransport}
+	first := &countingTransport{base: defaultTransport}
+	second := &countingTransport{base: defaultTransport}
+	routes := &clientTransports{base: base, transports: map[string]http.RoundTripper{}}
+	client := &http.Client{Transport: routes}
+	keys := []string{routes.add(first), routes.add(second), routes.add(second), ""}
+
+	for _, key := range keys {
+		request, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		if key != "" {
+			request.Header.Set(clientTransportHeader, key)
+		}
+		response, err := client.Do(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			t.Errorf("request with key %q returned %d, want the header removed", key, response.StatusCode)
+		}
+	}
+	if base.requests != 1 || first.requests != 1 || second.requests != 2 {
+		t.Errorf("requests = %d, %d, %d, want 1, 1, 2", base.requests, first.requests, second.requests)
+	}
+
+	request, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	request.Header.Set(clientTransportHeader, "unknown")
+	if _, err := client.Do(request); err == nil {
+		t.Error("request to an unknown transport was sent")
+	}
+}
@@ -2,26 +2,75 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/agext/levenshtein"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jc0b/go-jamfpro-api/jamfpro"
 	"math/rand"
+	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 )
 
-func resourceImportStatePassthroughJamfProID(ctx context.Context, name string, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+// importIDLookup resolves the value of a prefixed import ID, such as the HQ
+// of name:HQ, to the ID of an object.
+type importIDLookup func(ctx context.Context, value string) (int, diag.Diagnostics)
+
+// resourceImportStatePassthroughJamfProID imports an object by its ID, or by
+// an import ID of the form <prefix>:<value> resolved by the lookup of prefix.
+func resourceImportStatePassthroughJamfProID(ctx context.Context, name string, request resource.ImportStateRequest, response *resource.ImportStateResponse, lookups map[string]importIDLookup) {
 	jamfProId, err := strconv.ParseInt(request.ID, 10, 64)
 	if err != nil {
-		response.Diagnostics.AddError(
-			"Invalid resource ID",
-			fmt.Sprintf("Jamf Pro %s ID must be an integer", name),
+		prefix, value, _ := strings.Cut(request.ID, ":")
+		lookup, ok := lookups[prefix]
+		if !ok || value == "" {
+			formats := make([]string, 0, len(lookups))
+			for prefix := range lookups {
+				formats = append(formats, prefix+":<value>")
+			}
+			sort.Strings(formats)
+			response.Diagnostics.AddError(
+				"Invalid resource ID",
+				fmt.Sprintf("Jamf Pro %s ID must be an integer, or one of %s", name, strings.Join(formats, ", ")),
+			)
+			return
+		}
+
+		id, diags := lookup(ctx, value)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		jamfProId = int64(id)
+	}
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), types.Int64Value(jamfProId))...)
+}
+
+// lookUpComputerGroupIDByName returns the ID of the computer group with the
+// given name. Jamf Pro requires the names of computer groups to be unique.
+func lookUpComputerGroupIDByName(ctx context.Context, client *jamfpro.Client, name string) (int, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	group, resp, err := client.ComputerGroups.GetByName(ctx, name)
+	if isNotFound(resp) {
+		diags.AddError(
+			"No matching computer group",
+			fmt.Sprintf("No computer group named '%s' exists in Jamf Pro.", name),
 		)
-	} else {
-		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), types.Int64Value(jamfProId))...)
+		return 0, diags
 	}
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to look up computer group '%s', got error: %s", name, err),
+		)
+		return 0, diags
+	}
+	return group.Id, diags
 }
 
 func AreGroupsEquivalent(planned, actual *jamfpro.ComputerGroup) bool {
@@ -35,13 +84,24 @@ func AreGroupsEquivalent(planned, actual *jamfpro.ComputerGroup) bool {
 	if planned.Id != actual.Id {
 		return false
 	}
-	for i, v := range planned.Computers {
-		if v != actual.Computers[i] {
+	if len(planned.Criteria) != len(actual.Criteria) {
+		return false
+	}
+	// The members of smart groups follow from the criteria, and change on their own.
+	if !planned.IsSmart && len(planned.Criteria) == 0 {
+		if len(planned.Computers) != len(actual.Computers) {
 			return false
 		}
+		for i, v := range planned.Computers {
+			if v != actual.Computers[i] {
+				return false
+			}
+		}
 	}
-	for i, v := range planned.Criteria {
-		if v != actual.Criteria[i] {
+	// Jamf Pro evaluates criteria by priority, whatever order they are listed in.
+	actualCriteria := criteriaByPriority(actual.Criteria)
+	for i, v := range criteriaByPriority(planned.Criteria) {
+		if v != actualCriteria[i] {
 			return false
 		}
 	}
@@ -49,6 +109,129 @@ func AreGroupsEquivalent(planned, actual *jamfpro.ComputerGroup) bool {
 	return true
 }
 
+// readOnlyError describes an operation refused because the provider is read-only, as a summary and detail.
+func readOnlyError(operation string, typeName string) (string, string) {
+	return "Provider is read-only",
+		fmt.Sprintf("Unable to %s %s: the provider is configured with read_only (or JAMF_READ_ONLY), so no changes "+
+			"are made to Jamf Pro.", operation, typeName)
+}
+
+// lookUpIDByName returns the ID of the only object of a Jamf Pro API collection
+// with the given name. kind names the type of the objects in diagnostics.
+func lookUpIDByName(ctx context.Context, api *apiClient, collection string, nameField string, kind string, name string) (int, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	ids, err := api.findIDsByName(ctx, collection, nameField, name)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to look up %s '%s', got error: %s", kind, name, err),
+		)
+		return 0, diags
+	}
+	switch len(ids) {
+	case 0:
+		diags.AddError(
+			"No matching "+kind,
+			fmt.Sprintf("No %s named '%s' exists in Jamf Pro.", kind, name),
+		)
+	case 1:
+		return ids[0], diags
+	default:
+		diags.AddError(
+			"Several matching "+kind+"s",
+			fmt.Sprintf("%d %ss are named '%s' (IDs %v). Refer to it by its ID instead.", len(ids), kind, name, ids),
+		)
+	}
+	return 0, diags
+}
+
+// isNotFound reports whether Jamf Pro responded that an object does not exist.
+func isNotFound(resp *jamfpro.Response) bool {
+	return resp != nil && resp.StatusCode == http.StatusNotFound
+}
+
+// readJamfProObject gets the object with the given ID, retrying while Jamf Pro
+// reports it missing since a recent change may not have propagated through the
+// cluster yet. found is false if the object is still missing after the retries.
+func readJamfProObject[T any](ctx context.Context, retry retryPolicy, get func(context.Context, int) (T, *jamfpro.Response, error), id int) (object T, found bool, err error) {
+	err = retry.poll(ctx, func() (bool, error) {
+		var resp *jamfpro.Response
+		var getErr error
+		object, resp, getErr = get(ctx, id)
+		if isNotFound(resp) {
+			return false, nil
+		}
+		if getErr != nil {
+			return false, getErr
+		}
+		found = true
+		return true, nil
+	})
+	if errors.Is(err, errNotPropagated) {
+		return object, false, nil
+	}
+	return object, found, err
+}
+
+// readAPIObject is readJamfProObject for objects that jamfpro.Client does not
+// cover, decoding the object at path of the Jamf Pro API into object.
+func readAPIObject[T any](ctx context.Context, retry retryPolicy, api *apiClient, path string) (object T, found bool, err error) {
+	err = retry.poll(ctx, func() (bool, error) {
+		getErr := api.getJSON(ctx, path, nil, &object)
+		if isNotFoundError(getErr) {
+			return false, nil
+		}
+		if getErr != nil {
+			return false, getErr
+		}
+		found = true
+		return true, nil
+	})
+	if errors.Is(err, errNotPropagated) {
+		return object, false, nil
+	}
+	return object, found, err
+}
+
+// waitForComputerGroup polls a computer group until Jamf Pro serves it as
+// expected, since changes to groups take a while to propagate through a cluster.
+// It polls until ctx is done, so the wait is bounded by the timeout of the
+// operation. It returns the group as last observed, which is nil if it was not
+// found.
+func waitForComputerGroup(ctx context.Context, client *jamfpro.Client, retry retryPolicy, expected *jamfpro.ComputerGroup) (*jamfpro.ComputerGroup, error) {
+	var observed *jamfpro.ComputerGroup
+	err := retry.waitFor(ctx, func() (bool, error) {
+		actual, resp, err := client.ComputerGroups.GetByID(ctx, expected.Id)
+		if isNotFound(resp) {
+			observed = nil
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		observed = actual
+		return AreGroupsEquivalent(expected, actual), nil
+	})
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		// A last check cut short by the deadline is a timeout as well.
+		err = context.DeadlineExceeded
+	}
+	return observed, err
+}
+
+// nearestMatch returns the candidate closest to value, ignoring case, if it is
+// at most maxDistance edits away.
+func nearestMatch(value string, candidates []string, maxDistance int) (string, bool) {
+	match, matchDistance := "", maxDistance+1
+	for _, candidate := range candidates {
+		distance := levenshtein.Distance(strings.ToLower(value), strings.ToLower(candidate), nil)
+		if distance < matchDistance {
+			match, matchDistance = candidate, distance
+		}
+	}
+	return match, matchDistance <= maxDistance
+}
+
 func randomSerialNumber() string {
 	letterBytes := "CDFGHJKLMNPQRSTVWXYZ1234567890"
 	maxLength := 12